@@ -0,0 +1,57 @@
+// Package adapter adapts the engines to the Source interfaces of math/rand
+// and math/rand/v2, so that they can be used with *rand.Rand, and the
+// sources of math/rand/v2 to prng.Engine
+//
+// The adapters of math/rand/v2, NewSource, NewRandV2 and SourceEngine,
+// require Go 1.22 or later.
+package adapter
+
+import (
+	"math/rand"
+
+	"github.com/shivakar/random/prng"
+)
+
+var (
+	source64 *Source64
+	_        rand.Source64 = source64
+)
+
+// Source64 is a math/rand Source64 drawing from an engine.
+// As the sources returned by rand.NewSource, a Source64 is not safe for
+// concurrent use by multiple goroutines.
+type Source64 struct {
+	e prng.Engine
+}
+
+// NewSource64 returns a new math/rand Source64 drawing from the engine
+func NewSource64(e prng.Engine) *Source64 {
+	return &Source64{e: e}
+}
+
+// NewRand returns a new *rand.Rand drawing from the engine
+func NewRand(e prng.Engine) *rand.Rand {
+	return rand.New(NewSource64(e))
+}
+
+// Engine returns the engine the source draws from
+func (s *Source64) Engine() prng.Engine {
+	return s.e
+}
+
+// Int63 returns a pseudo-random 63-bit value in [0, 2^63) as an int64,
+// from the upper 63 bits of Uint64 of the engine
+func (s *Source64) Int63() int64 {
+	return int64(s.e.Uint64() >> 1)
+}
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64
+func (s *Source64) Uint64() uint64 {
+	return s.e.Uint64()
+}
+
+// Seed uses the provided value to initialize the engine.
+// If the seed provided is 0, the engine is initialized with current time
+func (s *Source64) Seed(seed int64) {
+	s.e.Seed(uint64(seed))
+}
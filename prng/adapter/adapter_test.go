@@ -0,0 +1,49 @@
+package adapter_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shivakar/random/prng/adapter"
+	"github.com/shivakar/random/prng/splitmix64"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Source64(t *testing.T) {
+	assert := assert.New(t)
+
+	e := splitmix64.New(20170612)
+	s := adapter.NewSource64(splitmix64.New(20170612))
+	assert.Equal(e.Uint64(), s.Uint64())
+	assert.Equal(int64(e.Uint64()>>1), s.Int63())
+
+	// Checking that Seed seeds the engine
+	s.Seed(1)
+	assert.Equal(uint64(1), s.Engine().GetSeed())
+	assert.Equal(splitmix64.New(1).Uint64(), s.Uint64())
+	s.Seed(0)
+	assert.NotEqual(uint64(0), s.Engine().GetSeed())
+
+	// Checking that *rand.Rand draws from the engine
+	e = splitmix64.New(20170612)
+	r := adapter.NewRand(splitmix64.New(20170612))
+	assert.Equal(e.Uint64(), r.Uint64())
+	assert.Equal(int64(e.Uint64()>>1), r.Int63())
+}
+
+// Example - Adapter Usage
+func ExampleNewRand() {
+	// Create a new *rand.Rand drawing from a SplitMix64 engine
+	r := adapter.NewRand(splitmix64.New(20170612))
+
+	fmt.Println("SplitMix64: seed = 20170612; rand.Rand.Uint64()")
+	for i := 0; i < 3; i++ {
+		fmt.Println(r.Uint64())
+	}
+
+	// Output:
+	// SplitMix64: seed = 20170612; rand.Rand.Uint64()
+	// 17462441621469506309
+	// 14349248600832693881
+	// 10663058410636140827
+}
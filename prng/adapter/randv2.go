@@ -0,0 +1,197 @@
+//go:build go1.22
+
+package adapter
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+var (
+	sourceEngine *SourceEngine
+	_            prng.Engine        = sourceEngine
+	_            prng.StateRestorer = sourceEngine
+)
+
+// NewSource returns the engine as a math/rand/v2 Source. An engine already
+// implements rand.Source, whose only method is Uint64: NewSource only
+// documents the conversion.
+func NewSource(e prng.Engine) rand.Source {
+	return e
+}
+
+// NewRandV2 returns a new math/rand/v2 *rand.Rand drawing from the engine
+func NewRandV2(e prng.Engine) *rand.Rand {
+	return rand.New(e)
+}
+
+// SourceEngine is an engine drawing from a math/rand/v2 Source, e.g. a
+// *rand.PCG or a *rand.ChaCha8.
+//
+// Seed is only supported for the sources that SourceEngine knows how to
+// seed: *rand.PCG, *rand.ChaCha8 and the sources with a Seed(uint64) method.
+// Their seeds are derived from the seed of the engine with SplitMix64.
+//
+// GetState and SetState are only supported for the sources implementing
+// encoding.BinaryMarshaler and encoding.BinaryUnmarshaler, as *rand.PCG and
+// *rand.ChaCha8 do, and a state can only be restored into a SourceEngine of
+// a source of the same type. SourceEngine is not registered with prng, and
+// its states cannot be restored by prng.FromState.
+//
+// Reset reseeds the source if Seed has been called, and otherwise restores
+// the state of the source saved by NewSourceEngine. Reset therefore panics
+// for a source that can be neither saved nor seeded, or that cannot be
+// saved and has not been seeded.
+type SourceEngine struct {
+	src  rand.Source
+	seed uint64
+	init []byte
+}
+
+// NewSourceEngine returns a new engine drawing from the source src, which is
+// used as is: GetSeed returns 0 until Seed is called. The state of src is
+// saved for Reset if src implements encoding.BinaryMarshaler and
+// encoding.BinaryUnmarshaler.
+func NewSourceEngine(src rand.Source) *SourceEngine {
+	e := &SourceEngine{src: src}
+	if m, ok := src.(encoding.BinaryMarshaler); ok {
+		if _, ok := src.(encoding.BinaryUnmarshaler); ok {
+			e.init, _ = m.MarshalBinary()
+		}
+	}
+	return e
+}
+
+// Source returns the source the engine draws from
+func (e *SourceEngine) Source() rand.Source {
+	return e.src
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64.
+// Uint64 advances the internal state of the engine.
+func (e *SourceEngine) Uint64() uint64 {
+	return e.src.Uint64()
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (e *SourceEngine) Float64() float64 {
+	return float64(e.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (e *SourceEngine) Float64OO() float64 {
+	return (float64(e.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the source.
+// If the seed provided is 0, the source is initialized with current time.
+// Seed panics if the source cannot be seeded.
+func (e *SourceEngine) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	sm := splitmix64.New(seed)
+	switch src := e.src.(type) {
+	case *rand.PCG:
+		src.Seed(sm.Uint64(), sm.Uint64())
+	case *rand.ChaCha8:
+		var key [32]byte
+		for i := 0; i < len(key); i += 8 {
+			binary.LittleEndian.PutUint64(key[i:], sm.Uint64())
+		}
+		src.Seed(key)
+	case interface{ Seed(uint64) }:
+		src.Seed(sm.Uint64())
+	default:
+		panic(fmt.Sprintf("SourceEngine: Seed not supported by %T", e.src))
+	}
+	e.seed = seed
+}
+
+// GetSeed returns the seed used to initialize the engine, or 0 if Seed has
+// not been called
+func (e *SourceEngine) GetSeed() uint64 {
+	return e.seed
+}
+
+// GetState returns the internal state of the engine as []byte.
+// GetState panics if the source does not implement
+// encoding.BinaryMarshaler.
+func (e *SourceEngine) GetState() []byte {
+	m, ok := e.src.(encoding.BinaryMarshaler)
+	if !ok {
+		panic(fmt.Sprintf("SourceEngine: GetState not supported by %T", e.src))
+	}
+	b, err := m.MarshalBinary()
+	if err != nil {
+		panic("SourceEngine: Error encoding state\n" + err.Error())
+	}
+	return state.Encode("randv2", e.seed, b)
+}
+
+// SetState sets the internal state of the engine from a []byte.
+// SetState panics if the source does not implement
+// encoding.BinaryUnmarshaler.
+func (e *SourceEngine) SetState(b []byte) {
+	if err := e.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// Reset reverts the source to its state after the last call to Seed, or to
+// its state when the engine was created if Seed has not been called.
+// Reset panics if the source has not been seeded and its state could not be
+// saved by NewSourceEngine.
+func (e *SourceEngine) Reset() {
+	if e.seed != 0 {
+		e.Seed(e.seed)
+		return
+	}
+	if e.init == nil {
+		panic(fmt.Sprintf("SourceEngine: Reset not supported by %T", e.src))
+	}
+	if err := e.src.(encoding.BinaryUnmarshaler).UnmarshalBinary(e.init); err != nil {
+		panic("SourceEngine: Error restoring state\n" + err.Error())
+	}
+}
+
+/*
+ * Implement 'StateRestorer' interface
+ */
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if the source does not implement encoding.BinaryUnmarshaler or
+// rejects the state, in which case the engine is left unchanged
+func (e *SourceEngine) SetStateE(b []byte) error {
+	var seed uint64
+	d := state.NewDecoder("randv2", b)
+	d.Read(&seed)
+	payload := make([]byte, d.Len())
+	d.Read(payload)
+	if err := d.Err(); err != nil {
+		return err
+	}
+	u, ok := e.src.(encoding.BinaryUnmarshaler)
+	if !ok {
+		err := fmt.Errorf("SetState not supported by %T", e.src)
+		return &prng.StateError{Algorithm: "randv2", Err: err}
+	}
+	if err := u.UnmarshalBinary(payload); err != nil {
+		return &prng.StateError{Algorithm: "randv2", Err: err}
+	}
+	e.seed = seed
+	return nil
+}
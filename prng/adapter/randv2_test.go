@@ -0,0 +1,117 @@
+//go:build go1.22
+
+package adapter_test
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/adapter"
+	"github.com/shivakar/random/prng/splitmix64"
+	"github.com/stretchr/testify/assert"
+)
+
+// source is a math/rand/v2 Source that can neither be seeded nor saved
+type source struct{ x uint64 }
+
+func (s *source) Uint64() uint64 { s.x++; return s.x }
+
+func Test_Source(t *testing.T) {
+	assert := assert.New(t)
+
+	e := splitmix64.New(20170612)
+	r := adapter.NewRandV2(splitmix64.New(20170612))
+	assert.Equal(e.Uint64(), r.Uint64())
+	e = splitmix64.New(20170612)
+	s := adapter.NewSource(splitmix64.New(20170612))
+	assert.Equal(e.Uint64(), s.Uint64())
+}
+
+func Test_SourceEngine_Uint64(t *testing.T) {
+	assert := assert.New(t)
+
+	pcg := rand.NewPCG(1, 2)
+	e := adapter.NewSourceEngine(rand.NewPCG(1, 2))
+	assert.Zero(e.GetSeed())
+	for i := 0; i < 10; i++ {
+		assert.Equal(pcg.Uint64(), e.Uint64())
+	}
+	assert.Equal(float64(pcg.Uint64()>>11)/float64(1<<53), e.Float64())
+	v := e.Float64OO()
+	assert.True(v > 0 && v < 1)
+}
+
+func Test_SourceEngine_SeedReset(t *testing.T) {
+	assert := assert.New(t)
+
+	sources := []rand.Source{
+		rand.NewPCG(1, 2),
+		rand.NewChaCha8([32]byte{}),
+		splitmix64.New(1),
+	}
+	for _, src := range sources {
+		// Checking that Reset restores the state of an unseeded source
+		e1 := adapter.NewSourceEngine(src)
+		w := e1.Uint64()
+		_ = e1.Uint64()
+		e1.Reset()
+		assert.Equal(w, e1.Uint64())
+		assert.Zero(e1.GetSeed())
+
+		e1.Seed(20170612)
+		assert.Equal(uint64(20170612), e1.GetSeed())
+		v := e1.Uint64()
+		e1.Reset()
+		assert.Equal(v, e1.Uint64())
+		e1.Seed(1)
+		assert.NotEqual(v, e1.Uint64())
+		e1.Seed(0)
+		assert.NotEqual(uint64(0), e1.GetSeed())
+	}
+
+	e := adapter.NewSourceEngine(&source{})
+	assert.Panics(func() { e.Seed(1) })
+	assert.Panics(func() { e.Reset() })
+}
+
+func Test_SourceEngine_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, newSource := range []func() rand.Source{
+		func() rand.Source { return rand.NewPCG(1, 2) },
+		func() rand.Source { return rand.NewChaCha8([32]byte{1}) },
+	} {
+		e1 := adapter.NewSourceEngine(newSource())
+		e1.Seed(20170612)
+		for i := 0; i < 10; i++ {
+			_ = e1.Uint64()
+		}
+		e2 := adapter.NewSourceEngine(newSource())
+		e2.SetState(e1.GetState())
+		assert.Equal(uint64(20170612), e2.GetSeed())
+		for i := 0; i < 10; i++ {
+			assert.Equal(e1.Uint64(), e2.Uint64())
+		}
+	}
+
+	// State of a source of another type
+	e1 := adapter.NewSourceEngine(rand.NewPCG(1, 2))
+	e2 := adapter.NewSourceEngine(rand.NewChaCha8([32]byte{}))
+	want := e2.GetState()
+	var se *prng.StateError
+	assert.True(errors.As(e2.SetStateE(e1.GetState()), &se))
+	assert.Equal(want, e2.GetState())
+	assert.Panics(func() { e2.SetState(e1.GetState()) })
+
+	// State of another engine, and truncated state
+	assert.Error(e1.SetStateE(splitmix64.New(1).GetState()))
+	b := e1.GetState()
+	assert.Error(e1.SetStateE(b[:len(b)-1]))
+
+	// Source that cannot be saved
+	e := adapter.NewSourceEngine(&source{})
+	assert.Panics(func() { _ = e.GetState() })
+	assert.Panics(func() { e.SetState(b) })
+}
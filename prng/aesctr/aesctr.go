@@ -0,0 +1,274 @@
+package aesctr
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+var (
+	aesctr *AESCTR
+	_      prng.Engine        = aesctr
+	_      prng.Advancer      = aesctr
+	_      prng.StateRestorer = aesctr
+)
+
+func init() {
+	prng.Register("aesctr", func(seed uint64) prng.Engine { return New(seed) })
+	gob.Register(new(AESCTR))
+}
+
+// blockWords is the number of uint64 values generated per AES block
+const blockWords = aes.BlockSize / 8
+
+// AESCTR implements a PRNG using the keystream of AES-128 in counter mode.
+// The 128-bit key and the 64-bit stream id select one of 2^192 independent
+// sequences, each consisting of 2^64 blocks of two uint64 values.
+type AESCTR struct {
+	seed    uint64
+	key     [16]byte
+	stream  uint64
+	counter uint64
+	block   cipher.Block
+	buf     [blockWords]uint64
+	index   int
+}
+
+// New returns a new instance of the AESCTR PRNG Engine using stream 0. The
+// key is derived from the seed using SplitMix64.
+// If the seed provided is 0, the engine is initialized with current time
+func New(seed uint64) *AESCTR {
+	r := new(AESCTR)
+	r.Seed(seed)
+	return r
+}
+
+// NewWithKey returns a new instance of the AESCTR PRNG Engine using the
+// given 128-bit key and stream. GetSeed returns 0 for engines created by
+// NewWithKey.
+func NewWithKey(key [16]byte, stream uint64) *AESCTR {
+	r := new(AESCTR)
+	r.stream = stream
+	r.SetKey(key)
+	return r
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64.
+// Uint64 advances the internal state of the engine.
+func (a *AESCTR) Uint64() uint64 {
+	if a.index == blockWords {
+		a.refill()
+	}
+	v := a.buf[a.index]
+	a.index++
+	return v
+}
+
+// refill encrypts the counter block of the current counter and moves the
+// counter to the next block
+func (a *AESCTR) refill() {
+	var in, out [aes.BlockSize]byte
+	binary.BigEndian.PutUint64(in[:8], a.stream)
+	binary.BigEndian.PutUint64(in[8:], a.counter)
+	a.block.Encrypt(out[:], in[:])
+	for i := range a.buf {
+		a.buf[i] = binary.LittleEndian.Uint64(out[8*i:])
+	}
+	a.counter++
+	a.index = 0
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (a *AESCTR) Float64() float64 {
+	return float64(a.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (a *AESCTR) Float64OO() float64 {
+	return (float64(a.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine on its current
+// stream. The key is made of two consecutive outputs of SplitMix64 seeded
+// with seed, in little-endian byte order.
+// If the seed provided is 0, the engine is initialized with current time
+func (a *AESCTR) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	a.seed = seed
+	ms := splitmix64.New(seed)
+	var key [16]byte
+	binary.LittleEndian.PutUint64(key[:8], ms.Uint64())
+	binary.LittleEndian.PutUint64(key[8:], ms.Uint64())
+	a.SetKey(key)
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (a *AESCTR) GetSeed() uint64 { return a.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (a *AESCTR) GetState() []byte {
+	return state.Encode("aesctr", a.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (a *AESCTR) fields() []interface{} {
+	return []interface{}{
+		uint64(a.seed),
+		a.key,
+		uint64(a.stream),
+		uint64(a.counter),
+		uint64(a.index),
+	}
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (a *AESCTR) SetState(b []byte) {
+	if err := a.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of AESCTR, e.g. if it is truncated or if its
+// position is beyond the end of a block
+func (a *AESCTR) SetStateE(b []byte) error {
+	return a.setState(state.NewDecoder("aesctr", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (a *AESCTR) setState(d *state.Decoder) error {
+	var seed, stream, counter, index uint64
+	var key [16]byte
+	d.Read(&seed, &key, &stream, &counter, &index)
+	if index > blockWords {
+		d.Fail(fmt.Errorf("Invalid position %d in block", index))
+	}
+	if err := d.Err(); err != nil {
+		return err
+	}
+	a.seed = seed
+	a.stream = stream
+	a.SetKey(key)
+	a.counter = counter
+	if index < blockWords {
+		// Regenerate the partially consumed block
+		a.counter--
+		a.refill()
+		a.index = int(index)
+	}
+	return nil
+}
+
+// Reset reverts the internal state of the engine to the start of its
+// stream, keeping the seed, key and stream
+func (a *AESCTR) Reset() {
+	a.Seek(0)
+}
+
+// Advance advances the internal state of the engine by n steps, i.e. it is
+// equivalent to calling Uint64 n times, in constant time
+func (a *AESCTR) Advance(n uint64) {
+	blk, off := a.counter, uint64(0)
+	if a.index < blockWords {
+		blk, off = a.counter-1, uint64(a.index)
+	}
+	blk += n / blockWords
+	off += n % blockWords
+	if off >= blockWords {
+		blk++
+		off -= blockWords
+	}
+	a.Seek(blk)
+	if off > 0 {
+		a.refill()
+		a.index = int(off)
+	}
+}
+
+// Seek moves the engine to the start of keystream block b, i.e. the next
+// call to Uint64 returns the first value of that block. Block b starts with
+// the (2*b)-th value of the stream.
+func (a *AESCTR) Seek(b uint64) {
+	a.counter = b
+	a.index = blockWords
+}
+
+// GetStream returns the stream of the engine
+func (a *AESCTR) GetStream() uint64 { return a.stream }
+
+// SetStream selects the stream of the engine and moves the engine to the
+// start of the new stream
+func (a *AESCTR) SetStream(stream uint64) {
+	a.stream = stream
+	a.Seek(0)
+}
+
+// SetKey sets the 128-bit AES key of the engine and moves the engine to the
+// start of its current stream. The seed is left unchanged: Reset keeps the
+// key, whereas Seed replaces it with the key derived from the new seed.
+func (a *AESCTR) SetKey(key [16]byte) {
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		panic(strings.Join([]string{"aesctr: Error setting key", err.Error()}, "\n"))
+	}
+	a.key = key
+	a.block = block
+	a.Seek(0)
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (a *AESCTR) MarshalBinary() ([]byte, error) { return a.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (a *AESCTR) UnmarshalBinary(b []byte) error { return a.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (a *AESCTR) MarshalText() ([]byte, error) {
+	return state.MarshalText(a.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (a *AESCTR) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("aesctr", text)
+	if err != nil {
+		return err
+	}
+	return a.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (a *AESCTR) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("aesctr", a.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (a *AESCTR) UnmarshalJSON(data []byte) error {
+	return a.setState(state.NewJSONDecoder("aesctr", data))
+}
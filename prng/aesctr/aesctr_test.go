@@ -0,0 +1,277 @@
+package aesctr_test
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/aesctr"
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadir = filepath.Join("..", "..", "data", "aesctr")
+var longTest bool
+
+// TestMain is the entry point for testing
+func TestMain(m *testing.M) {
+	longTest = prngtest.ParseCommandLine()
+	os.Exit(m.Run())
+}
+
+// keystream returns the next 8*n bytes of keystream generated by the engine
+func keystream(a *aesctr.AESCTR, n int) []byte {
+	b := make([]byte, 8*n)
+	for i := 0; i < n; i++ {
+		binary.LittleEndian.PutUint64(b[8*i:], a.Uint64())
+	}
+	return b
+}
+
+func Test_AESCTR_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := aesctr.New(0)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+}
+
+func Test_AESCTR_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed and stream remain same after getting and setting states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := aesctr.New(seed)
+		r.SetStream(seed + 1)
+		states[i] = r.GetState()
+	}
+	for i, state := range states {
+		r := aesctr.New(0)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+		assert.Equal(seeds[i]+1, r.GetStream())
+	}
+
+	// Checking that the streams remain same after getting and setting
+	// states, at the start of a block, within a block and at its end
+	for _, n := range []int{0, 1, 2, 5} {
+		r1 := aesctr.New(0)
+		for i := 0; i < n; i++ {
+			_ = r1.Uint64()
+		}
+		r2 := aesctr.New(0)
+		r2.SetState(r1.GetState())
+		for i := 0; i < 10; i++ {
+			assert.Equal(r1.Uint64(), r2.Uint64())
+		}
+		assert.Equal(r1.Float64(), r2.Float64())
+		assert.Equal(r1.Float64OO(), r2.Float64OO())
+	}
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := aesctr.New(0)
+		r1.SetState([]byte("Hell"))
+	})
+	assert.Panics(func() {
+		r1 := aesctr.New(0)
+		r1.SetState(nil)
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("aesctx"))
+	assert.Panics(func() {
+		r1 := aesctr.New(0)
+		r1.SetState(buf.Bytes())
+	})
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("aesctr"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := aesctr.New(0)
+		r1.SetState(buf.Bytes())
+	})
+
+	// Position beyond the end of a block
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("aesctr"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, [16]byte{})
+	_ = binary.Write(buf, binary.LittleEndian, [3]uint64{0, 1, 3})
+	assert.Panics(func() {
+		r1 := aesctr.New(0)
+		r1.SetState(buf.Bytes())
+	})
+}
+
+func Test_AESCTR_Uint64(t *testing.T) {
+	e := aesctr.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "aesctr-*-uint64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_AESCTR_Float64(t *testing.T) {
+	e := aesctr.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "aesctr-*-float64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_AESCTR_Float64OO(t *testing.T) {
+	e := aesctr.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "aesctr-*-float64oo-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_AESCTR_SP80038A(t *testing.T) {
+	assert := assert.New(t)
+
+	// NIST SP 800-38A, F.5.1 CTR-AES128.Encrypt: the keystream is the
+	// ciphertext xored with the plaintext, for the initial counter block
+	// f0f1f2f3f4f5f6f7f8f9fafbfcfdfeff
+	var key [16]byte
+	k, _ := hex.DecodeString("2b7e151628aed2a6abf7158809cf4f3c")
+	copy(key[:], k)
+	r := aesctr.NewWithKey(key, 0xf0f1f2f3f4f5f6f7)
+	assert.Zero(r.GetSeed())
+	r.Seek(0xf8f9fafbfcfdfeff)
+	expected, _ := hex.DecodeString(
+		"ec8cdf7398607cb0f2d21675ea9ea1e4362b7c3c6773516318a077d7fc5073ae" +
+			"6a2cc3787889374fbeb4c81b17ba6c44e89c399ff0f198c6d40a31db156cabfe")
+	assert.Equal(expected, keystream(r, 8))
+
+	// Checking against the keystream of crypto/cipher
+	r = aesctr.New(20170612)
+	r.SetStream(42)
+	r.Seek(1 << 40)
+	c, err := prng.DecodeCheckpoint(r.GetState())
+	assert.NoError(err)
+	copy(key[:], c.Payload[8:24])
+	block, _ := aes.NewCipher(key[:])
+	iv := make([]byte, aes.BlockSize)
+	binary.BigEndian.PutUint64(iv, 42)
+	binary.BigEndian.PutUint64(iv[8:], 1<<40)
+	expected = make([]byte, 8*1000)
+	cipher.NewCTR(block, iv).XORKeyStream(expected, expected)
+	assert.Equal(expected, keystream(r, 1000))
+}
+
+func Test_AESCTR_AdvanceSeek(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking Advance against drawing, from every position within a block
+	steps := []uint64{0, 1, 2, 3, 5, 1024, 674637}
+	for _, n := range steps {
+		for offset := 0; offset < 2; offset++ {
+			r1 := aesctr.New(20170612)
+			r2 := aesctr.New(20170612)
+			for i := 0; i < offset; i++ {
+				_ = r1.Uint64()
+				_ = r2.Uint64()
+			}
+			r1.Advance(n)
+			for i := uint64(0); i < n; i++ {
+				_ = r2.Uint64()
+			}
+			assert.Equal(r2.Uint64(), r1.Uint64())
+		}
+	}
+
+	// Checking that Seek(b) is equivalent to Advance(2*b) from the start
+	r1 := aesctr.New(20170612)
+	r2 := aesctr.New(20170612)
+	_ = r1.Uint64()
+	r1.Seek(123456789)
+	r2.Advance(2 * 123456789)
+	for i := 0; i < 10; i++ {
+		assert.Equal(r2.Uint64(), r1.Uint64())
+	}
+	r1.Reset()
+	r2.Seed(20170612)
+	assert.Equal(r2.Uint64(), r1.Uint64())
+}
+
+func Test_AESCTR_StreamKey(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking that streams differ and that SetStream rewinds the engine
+	r1 := aesctr.New(20170612)
+	r2 := aesctr.New(20170612)
+	r2.SetStream(1)
+	assert.Equal(uint64(1), r2.GetStream())
+	assert.NotEqual(r1.Uint64(), r2.Uint64())
+	r1.SetStream(1)
+	r2.SetStream(1)
+	assert.Equal(r1.Uint64(), r2.Uint64())
+
+	// Checking that Reset keeps a key set by SetKey
+	var key [16]byte
+	for i := range key {
+		key[i] = byte(3 * i)
+	}
+	r1 = aesctr.NewWithKey(key, 5)
+	v := r1.Uint64()
+	r1.Seed(1)
+	r2 = aesctr.New(1)
+	r2.SetStream(5)
+	assert.Equal(r2.Uint64(), r1.Uint64())
+	r1.SetKey(key)
+	assert.Equal(v, r1.Uint64())
+	r1.Reset()
+	assert.Equal(uint64(1), r1.GetSeed())
+	assert.Equal(v, r1.Uint64())
+}
+
+// Benchmarks
+func Benchmark_AESCTR_Uint64(b *testing.B) {
+	rng := aesctr.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_AESCTR_Float64(b *testing.B) {
+	rng := aesctr.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_AESCTR_Float64OO(b *testing.B) {
+	rng := aesctr.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+// Example - AESCTR Usage
+func ExampleAESCTR() {
+	// Create a new instance of the AES-128-CTR engine
+	r := aesctr.New(20170612)
+
+	fmt.Println("AESCTR: seed = 20170612; Uint64()")
+	for i := 0; i < 3; i++ {
+		// Draw 64 random bits as a uint64
+		fmt.Println(r.Uint64())
+	}
+
+	// Output:
+	// AESCTR: seed = 20170612; Uint64()
+	// 16985364607210746050
+	// 14314257281272377633
+	// 9887971126230668532
+}
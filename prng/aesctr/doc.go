@@ -0,0 +1,29 @@
+// Package aesctr implements a cryptographically secure PRNG based on the
+// AES-128 block cipher in counter (CTR) mode, using crypto/aes, which uses
+// the AES instructions of the processor where available, e.g. AES-NI on
+// amd64.
+//
+// Block b of stream s is the encryption of the 128-bit counter block whose
+// most significant 64 bits are s and whose least significant 64 bits are b,
+// in big-endian byte order, and is read as two little-endian uint64 values.
+// The output of the engine is therefore the keystream of AES-128-CTR as in
+// NIST SP 800-38A, e.g. of crypto/cipher.NewCTR, with the initial counter
+// block s||b, as long as the block counter does not wrap around: after
+// block 2^64-1, the engine continues with block 0 of the same stream.
+//
+// Seed derives the key from a 64-bit seed using SplitMix64, which is
+// convenient for reproducible tests but provides at most 64 bits of
+// security; use NewWithKey or SetKey with a secret 128-bit key where
+// unpredictability matters.
+//
+// Since every block is computed independently, Seek moves the engine to any
+// block and Advance (prng.Advancer) skips any number of values in constant
+// time.
+//
+// References:
+//
+// FIPS 197, "Advanced Encryption Standard (AES)", NIST (2001).
+//
+// M. Dworkin, "Recommendation for Block Cipher Modes of Operation: Methods
+// and Techniques", NIST SP 800-38A (2001).
+package aesctr
@@ -0,0 +1,52 @@
+package chacha
+
+import "math/bits"
+
+// constants are the words "expand 32-byte k" of the ChaCha state
+var constants = [4]uint32{0x61707865, 0x3320646e, 0x79622d32, 0x6b206574}
+
+// quarterRound applies the ChaCha quarter-round to a, b, c and d
+func quarterRound(a, b, c, d uint32) (uint32, uint32, uint32, uint32) {
+	a += b
+	d = bits.RotateLeft32(d^a, 16)
+	c += d
+	b = bits.RotateLeft32(b^c, 12)
+	a += b
+	d = bits.RotateLeft32(d^a, 8)
+	c += d
+	b = bits.RotateLeft32(b^c, 7)
+	return a, b, c, d
+}
+
+// block computes the keystream block with the given 64-bit block counter
+// and 64-bit stream id. The counter occupies words 12 and 13 and the stream
+// words 14 and 15 of the input, as in the original ChaCha of Bernstein;
+// RFC 8439 instead uses a 32-bit counter in word 12 and a 96-bit nonce.
+// Each uint64 of out holds two consecutive little-endian keystream words.
+func block(out *[8]uint64, key *[8]uint32, counter, stream uint64, rounds int) {
+	in := [16]uint32{
+		constants[0], constants[1], constants[2], constants[3],
+		key[0], key[1], key[2], key[3],
+		key[4], key[5], key[6], key[7],
+		uint32(counter), uint32(counter >> 32),
+		uint32(stream), uint32(stream >> 32),
+	}
+	x := in
+	for i := 0; i < rounds; i += 2 {
+		// Column round
+		x[0], x[4], x[8], x[12] = quarterRound(x[0], x[4], x[8], x[12])
+		x[1], x[5], x[9], x[13] = quarterRound(x[1], x[5], x[9], x[13])
+		x[2], x[6], x[10], x[14] = quarterRound(x[2], x[6], x[10], x[14])
+		x[3], x[7], x[11], x[15] = quarterRound(x[3], x[7], x[11], x[15])
+		// Diagonal round
+		x[0], x[5], x[10], x[15] = quarterRound(x[0], x[5], x[10], x[15])
+		x[1], x[6], x[11], x[12] = quarterRound(x[1], x[6], x[11], x[12])
+		x[2], x[7], x[8], x[13] = quarterRound(x[2], x[7], x[8], x[13])
+		x[3], x[4], x[9], x[14] = quarterRound(x[3], x[4], x[9], x[14])
+	}
+	for i := range out {
+		lo := x[2*i] + in[2*i]
+		hi := x[2*i+1] + in[2*i+1]
+		out[i] = uint64(lo) | uint64(hi)<<32
+	}
+}
@@ -0,0 +1,288 @@
+package chacha
+
+import (
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+var (
+	chacha *ChaCha
+	_      prng.Engine        = chacha
+	_      prng.Advancer      = chacha
+	_      prng.StateRestorer = chacha
+)
+
+func init() {
+	prng.Register("chacha", func(seed uint64) prng.Engine { return New(seed, 20) })
+	gob.Register(new(ChaCha))
+}
+
+// blockWords is the number of uint64 values generated per keystream block
+const blockWords = 8
+
+// ChaCha implements a PRNG using the keystream of the ChaCha stream cipher
+// with 8, 12 or 20 rounds. The 256-bit key and the 64-bit stream id select
+// one of 2^320 independent sequences, each consisting of 2^64 blocks of
+// eight uint64 values.
+type ChaCha struct {
+	seed    uint64
+	rounds  int
+	key     [8]uint32
+	stream  uint64
+	counter uint64
+	buf     [blockWords]uint64
+	index   int
+}
+
+// New returns a new instance of the ChaCha PRNG Engine with the given number
+// of rounds, which must be 8, 12 or 20, using stream 0. The key is derived
+// from the seed using SplitMix64.
+// If the seed provided is 0, the engine is initialized with current time
+func New(seed uint64, rounds int) *ChaCha {
+	r := new(ChaCha)
+	r.setRounds(rounds)
+	r.Seed(seed)
+	return r
+}
+
+// NewWithKey returns a new instance of the ChaCha PRNG Engine with the given
+// number of rounds, which must be 8, 12 or 20, using the given 256-bit key
+// and stream. The key is read as eight little-endian 32-bit words, as in
+// RFC 8439. GetSeed returns 0 for engines created by NewWithKey.
+func NewWithKey(key [32]byte, stream uint64, rounds int) *ChaCha {
+	r := new(ChaCha)
+	r.setRounds(rounds)
+	r.stream = stream
+	r.SetKey(key)
+	return r
+}
+
+// setRounds sets the number of rounds, panicking if it is not supported
+func (c *ChaCha) setRounds(rounds int) {
+	if rounds != 8 && rounds != 12 && rounds != 20 {
+		panic(fmt.Sprintf("chacha: Unsupported number of rounds %d", rounds))
+	}
+	c.rounds = rounds
+}
+
+// Rounds returns the number of rounds used by the engine
+func (c *ChaCha) Rounds() int { return c.rounds }
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64.
+// Uint64 advances the internal state of the engine.
+func (c *ChaCha) Uint64() uint64 {
+	if c.index == blockWords {
+		c.refill()
+	}
+	v := c.buf[c.index]
+	c.index++
+	return v
+}
+
+// refill generates the keystream block at the current counter and moves the
+// counter to the next block
+func (c *ChaCha) refill() {
+	block(&c.buf, &c.key, c.counter, c.stream, c.rounds)
+	c.counter++
+	c.index = 0
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (c *ChaCha) Float64() float64 {
+	return float64(c.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (c *ChaCha) Float64OO() float64 {
+	return (float64(c.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine on its current
+// stream. The key is made of four consecutive outputs of SplitMix64 seeded
+// with seed, in little-endian byte order.
+// If the seed provided is 0, the engine is initialized with current time
+func (c *ChaCha) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	c.seed = seed
+	ms := splitmix64.New(seed)
+	for i := 0; i < len(c.key); i += 2 {
+		v := ms.Uint64()
+		c.key[i] = uint32(v)
+		c.key[i+1] = uint32(v >> 32)
+	}
+	c.Seek(0)
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (c *ChaCha) GetSeed() uint64 { return c.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (c *ChaCha) GetState() []byte {
+	return state.Encode("chacha", c.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (c *ChaCha) fields() []interface{} {
+	return []interface{}{
+		uint64(c.seed),
+		uint64(c.rounds),
+		c.key,
+		uint64(c.stream),
+		uint64(c.counter),
+		uint64(c.index),
+	}
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (c *ChaCha) SetState(b []byte) {
+	if err := c.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of ChaCha, e.g. if it is truncated, if its
+// number of rounds is not supported or if its position is beyond the end of
+// a block
+func (c *ChaCha) SetStateE(b []byte) error {
+	return c.setState(state.NewDecoder("chacha", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (c *ChaCha) setState(d *state.Decoder) error {
+	var seed, rounds, stream, counter, index uint64
+	var key [8]uint32
+	d.Read(&seed, &rounds, &key, &stream, &counter, &index)
+	if rounds != 8 && rounds != 12 && rounds != 20 {
+		d.Fail(fmt.Errorf("Unsupported number of rounds %d", rounds))
+	}
+	if index > blockWords {
+		d.Fail(fmt.Errorf("Invalid position %d in block", index))
+	}
+	if err := d.Err(); err != nil {
+		return err
+	}
+	c.seed = seed
+	c.rounds = int(rounds)
+	c.key = key
+	c.stream = stream
+	c.counter = counter
+	c.index = blockWords
+	if index < blockWords {
+		// Regenerate the partially consumed block
+		c.counter--
+		c.refill()
+		c.index = int(index)
+	}
+	return nil
+}
+
+// Reset reverts the internal state of the engine to the start of its
+// stream, keeping the seed, key, stream and number of rounds
+func (c *ChaCha) Reset() {
+	c.Seek(0)
+}
+
+// Advance advances the internal state of the engine by n steps, i.e. it is
+// equivalent to calling Uint64 n times, in constant time
+func (c *ChaCha) Advance(n uint64) {
+	blk, off := c.counter, uint64(0)
+	if c.index < blockWords {
+		blk, off = c.counter-1, uint64(c.index)
+	}
+	blk += n / blockWords
+	off += n % blockWords
+	if off >= blockWords {
+		blk++
+		off -= blockWords
+	}
+	c.Seek(blk)
+	if off > 0 {
+		c.refill()
+		c.index = int(off)
+	}
+}
+
+// Seek moves the engine to the start of keystream block b, i.e. the
+// next call to Uint64 returns the first value of that block. Block b
+// starts with the (8*b)-th value of the stream.
+func (c *ChaCha) Seek(b uint64) {
+	c.counter = b
+	c.index = blockWords
+}
+
+// GetStream returns the stream of the engine
+func (c *ChaCha) GetStream() uint64 { return c.stream }
+
+// SetStream selects the stream of the engine and moves the engine to the
+// start of the new stream
+func (c *ChaCha) SetStream(stream uint64) {
+	c.stream = stream
+	c.Seek(0)
+}
+
+// SetKey sets the 256-bit key of the engine, read as eight little-endian
+// 32-bit words, and moves the engine to the start of its current stream.
+// The seed is left unchanged: Reset keeps the key, whereas Seed replaces it
+// with the key derived from the new seed.
+func (c *ChaCha) SetKey(key [32]byte) {
+	for i := range c.key {
+		c.key[i] = binary.LittleEndian.Uint32(key[4*i:])
+	}
+	c.Seek(0)
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (c *ChaCha) MarshalBinary() ([]byte, error) { return c.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (c *ChaCha) UnmarshalBinary(b []byte) error { return c.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (c *ChaCha) MarshalText() ([]byte, error) {
+	return state.MarshalText(c.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (c *ChaCha) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("chacha", text)
+	if err != nil {
+		return err
+	}
+	return c.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (c *ChaCha) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("chacha", c.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (c *ChaCha) UnmarshalJSON(data []byte) error {
+	return c.setState(state.NewJSONDecoder("chacha", data))
+}
@@ -0,0 +1,347 @@
+package chacha_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/chacha"
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadir = filepath.Join("..", "..", "data", "chacha")
+var longTest bool
+
+var rounds = []int{8, 12, 20}
+
+// TestMain is the entry point for testing
+func TestMain(m *testing.M) {
+	longTest = prngtest.ParseCommandLine()
+	os.Exit(m.Run())
+}
+
+// compareDraws compares output of the engine for each number of rounds
+// against the draws files of that variant matching pattern
+func compareDraws(t *testing.T, pattern string) {
+	for _, n := range rounds {
+		e := chacha.New(0, n)
+		name := fmt.Sprintf("chacha%d", n)
+		filenames := prngtest.GetDataFiles(datadir, name+pattern)
+		prngtest.CompareDraws(t, e, filenames, longTest)
+	}
+}
+
+// keystream returns the next 8*n bytes of keystream generated by the engine
+func keystream(c *chacha.ChaCha, n int) []byte {
+	b := make([]byte, 8*n)
+	for i := 0; i < n; i++ {
+		binary.LittleEndian.PutUint64(b[8*i:], c.Uint64())
+	}
+	return b
+}
+
+func Test_ChaCha_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := chacha.New(0, 20)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+
+	for _, n := range []int{0, 7, 10, 24} {
+		assert.Panics(func() {
+			_ = chacha.New(1, n)
+		})
+	}
+}
+
+func Test_ChaCha_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed, rounds and stream remain same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := chacha.New(seed, rounds[i%len(rounds)])
+		r.SetStream(seed + 1)
+		states[i] = r.GetState()
+	}
+	for i, state := range states {
+		r := chacha.New(0, 20)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+		assert.Equal(rounds[i%len(rounds)], r.Rounds())
+		assert.Equal(seeds[i]+1, r.GetStream())
+	}
+
+	// Checking that the streams remain same after getting and setting
+	// states, at the start of a block, within a block and at its end
+	for _, n := range []int{0, 3, 8, 13} {
+		r1 := chacha.New(0, 12)
+		for i := 0; i < n; i++ {
+			_ = r1.Uint64()
+		}
+		r2 := chacha.New(0, 8)
+		r2.SetState(r1.GetState())
+		for i := 0; i < 10; i++ {
+			assert.Equal(r1.Uint64(), r2.Uint64())
+		}
+		assert.Equal(r1.Float64(), r2.Float64())
+		assert.Equal(r1.Float64OO(), r2.Float64OO())
+	}
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := chacha.New(0, 20)
+		r1.SetState([]byte("Hell"))
+	})
+	assert.Panics(func() {
+		r1 := chacha.New(0, 20)
+		r1.SetState(nil)
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("chachi"))
+	assert.Panics(func() {
+		r1 := chacha.New(0, 20)
+		r1.SetState(buf.Bytes())
+	})
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("chacha"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(20))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := chacha.New(0, 20)
+		r1.SetState(buf.Bytes())
+	})
+
+	// Unsupported number of rounds and position beyond the end of a block
+	for _, v := range [][2]uint64{{10, 8}, {20, 9}} {
+		buf = new(bytes.Buffer)
+		_ = binary.Write(buf, binary.LittleEndian, []byte("chacha"))
+		_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+		_ = binary.Write(buf, binary.LittleEndian, v[0])
+		_ = binary.Write(buf, binary.LittleEndian, [8]uint32{})
+		_ = binary.Write(buf, binary.LittleEndian, uint64(0))
+		_ = binary.Write(buf, binary.LittleEndian, uint64(1))
+		_ = binary.Write(buf, binary.LittleEndian, v[1])
+		assert.Panics(func() {
+			r1 := chacha.New(0, 20)
+			r1.SetState(buf.Bytes())
+		})
+	}
+}
+
+func Test_ChaCha_Uint64(t *testing.T) {
+	compareDraws(t, "-*-uint64-*.txt")
+}
+
+func Test_ChaCha_Float64(t *testing.T) {
+	compareDraws(t, "-*-float64-*.txt")
+}
+
+func Test_ChaCha_Float64OO(t *testing.T) {
+	compareDraws(t, "-*-float64oo-*.txt")
+}
+
+func Test_ChaCha_RFC8439(t *testing.T) {
+	assert := assert.New(t)
+
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+
+	// RFC 8439, Section 2.3.2: block counter 1 and nonce
+	// 00:00:00:09:00:00:00:4a:00:00:00:00, i.e. the 64-bit counter
+	// 0x0900000000000001 and the stream 0x4a000000
+	r := chacha.NewWithKey(key, 0x4a000000, 20)
+	r.Seek(0x0900000000000001)
+	expected, _ := hex.DecodeString(
+		"10f1e7e4d13b5915500fdd1fa32071c4c7d1f4c733c068030422aa9ac3d46c4e" +
+			"d2826446079faa0914c2d705d98b02a2b5129cd1de164eb9cbd083e8a2503c4e")
+	assert.Equal(expected, keystream(r, 8))
+
+	// RFC 8439, Appendix A.1: ChaCha20 block functions
+	tests := []struct {
+		key      [32]byte
+		stream   uint64
+		block    uint64
+		expected string
+	}{
+		{[32]byte{}, 0, 0,
+			"76b8e0ada0f13d90405d6ae55386bd28bdd219b8a08ded1aa836efcc8b770dc7" +
+				"da41597c5157488d7724e03fb8d84a376a43b8f41518a11cc387b669b2ee6586"},
+		{[32]byte{}, 0, 1,
+			"9f07e7be5551387a98ba977c732d080dcb0f29a048e3656912c6533e32ee7aed" +
+				"29b721769ce64e43d57133b074d839d531ed1f28510afb45ace10a1f4b794d6f"},
+		{[32]byte{31: 1}, 0, 1,
+			"3aeb5224ecf849929b9d828db1ced4dd832025e8018b8160b82284f3c949aa5a" +
+				"8eca00bbb4a73bdad192b5c42f73f2fd4e273644c8b36125a64addeb006c13a0"},
+		{[32]byte{1: 0xff}, 0, 2,
+			"72d54dfbf12ec44b362692df94137f328fea8da73990265ec1bbbea1ae9af0ca" +
+				"13b25aa26cb4a648cb9b9d1be65b2c0924a66c54d545ec1b7374f4872e99f096"},
+		{[32]byte{}, 0x02000000 << 32, 0,
+			"c2c64d378cd536374ae204b9ef933fcd1a8b2288b3dfa49672ab765b54ee27c7" +
+				"8a970e0e955c14f3a88e741b97c286f75f8fc299e8148362fa198a39531bed6d"},
+	}
+	for _, test := range tests {
+		r := chacha.NewWithKey(test.key, test.stream, 20)
+		r.Seek(test.block)
+		expected, _ := hex.DecodeString(test.expected)
+		assert.Equal(expected, keystream(r, 8))
+	}
+}
+
+func Test_ChaCha_ReducedRounds(t *testing.T) {
+	assert := assert.New(t)
+
+	// First keystream block for an all-zero key and IV, from
+	// draft-strombergson-chacha-test-vectors, TC1 with a 256-bit key
+	tests := map[int]string{
+		8: "3e00ef2f895f40d67f5bb8e81f09a5a12c840ec3ce9a7f3b181be188ef711a1e" +
+			"984ce172b9216f419f445367456d5619314a42a3da86b001387bfdb80e0cfe42",
+		12: "9bf49a6a0755f953811fce125f2683d50429c3bb49e074147e0089a52eae155f" +
+			"0564f879d27ae3c02ce82834acfa8c793a629f2ca0de6919610be82f411326be",
+	}
+	for n, s := range tests {
+		r := chacha.NewWithKey([32]byte{}, 0, n)
+		assert.Equal(n, r.Rounds())
+		assert.Zero(r.GetSeed())
+		expected, _ := hex.DecodeString(s)
+		assert.Equal(expected, keystream(r, 8))
+	}
+}
+
+func Test_ChaCha_AdvanceSeek(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking Advance against drawing, from every position within a block
+	steps := []uint64{0, 1, 7, 8, 9, 17, 1024, 674637}
+	for _, n := range steps {
+		for offset := 0; offset < 8; offset++ {
+			r1 := chacha.New(20170612, 8)
+			r2 := chacha.New(20170612, 8)
+			for i := 0; i < offset; i++ {
+				_ = r1.Uint64()
+				_ = r2.Uint64()
+			}
+			r1.Advance(n)
+			for i := uint64(0); i < n; i++ {
+				_ = r2.Uint64()
+			}
+			assert.Equal(r2.Uint64(), r1.Uint64())
+		}
+	}
+
+	// Checking that Seek(b) is equivalent to Advance(8*b) from the start
+	r1 := chacha.New(20170612, 20)
+	r2 := chacha.New(20170612, 20)
+	_ = r1.Uint64()
+	r1.Seek(123456789)
+	r2.Advance(8 * 123456789)
+	for i := 0; i < 10; i++ {
+		assert.Equal(r2.Uint64(), r1.Uint64())
+	}
+	r1.Reset()
+	r2.Seed(20170612)
+	assert.Equal(r2.Uint64(), r1.Uint64())
+}
+
+func Test_ChaCha_StreamKey(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking that streams differ and that SetStream rewinds the engine
+	r1 := chacha.New(20170612, 20)
+	r2 := chacha.New(20170612, 20)
+	r2.SetStream(1)
+	assert.Equal(uint64(1), r2.GetStream())
+	assert.NotEqual(r1.Uint64(), r2.Uint64())
+	r1.SetStream(1)
+	r2.SetStream(1)
+	assert.Equal(r1.Uint64(), r2.Uint64())
+
+	// Checking that the key derived by Seed matches SetKey with the
+	// SplitMix64 outputs in little-endian byte order, and that Reset keeps
+	// a key set by SetKey
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(3 * i)
+	}
+	r1 = chacha.NewWithKey(key, 5, 12)
+	v := r1.Uint64()
+	r1.Seed(1)
+	r2 = chacha.New(1, 12)
+	r2.SetStream(5)
+	assert.Equal(r2.Uint64(), r1.Uint64())
+	r1.SetKey(key)
+	assert.Equal(v, r1.Uint64())
+	r1.Reset()
+	assert.Equal(uint64(1), r1.GetSeed())
+	assert.Equal(v, r1.Uint64())
+}
+
+// Benchmarks
+func Benchmark_ChaCha8_Uint64(b *testing.B) {
+	rng := chacha.New(0, 8)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_ChaCha12_Uint64(b *testing.B) {
+	rng := chacha.New(0, 12)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_ChaCha20_Uint64(b *testing.B) {
+	rng := chacha.New(0, 20)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_ChaCha20_Float64(b *testing.B) {
+	rng := chacha.New(0, 20)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_ChaCha20_Float64OO(b *testing.B) {
+	rng := chacha.New(0, 20)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+// Example - ChaCha20 Usage
+func ExampleChaCha() {
+	// Create a new instance of the ChaCha20 engine
+	r := chacha.New(20170612, 20)
+
+	fmt.Println("ChaCha20: seed = 20170612; Uint64()")
+	for i := 0; i < 3; i++ {
+		// Draw 64 random bits as a uint64
+		fmt.Println(r.Uint64())
+	}
+
+	// Output:
+	// ChaCha20: seed = 20170612; Uint64()
+	// 3832819321173627034
+	// 8320659243460623945
+	// 6471246364694145086
+}
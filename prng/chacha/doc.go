@@ -0,0 +1,34 @@
+// Package chacha implements a cryptographically secure PRNG based on the
+// ChaCha stream cipher with 8, 12 or 20 rounds.
+//
+// The output of the engine is the ChaCha keystream, read as little-endian
+// uint64 values. It uses the original layout of Bernstein with a 64-bit
+// block counter and a 64-bit stream id (nonce), so that each combination of
+// a 256-bit key and a stream yields 2^64 blocks of eight uint64 values.
+// Blocks of RFC 8439, which uses a 32-bit counter and a 96-bit nonce, are
+// obtained by setting the stream to the last 64 bits of the nonce and
+// seeking to the block whose lower 32 bits are the RFC 8439 counter and
+// whose upper 32 bits are the first 32 bits of the nonce.
+//
+// Seed derives the key from a 64-bit seed using SplitMix64, which is
+// convenient for reproducible tests but provides at most 64 bits of
+// security; use NewWithKey or SetKey with a secret 256-bit key where
+// unpredictability matters. ChaCha20 is the conservative choice; ChaCha8
+// and ChaCha12 are faster and are not known to be distinguishable from
+// random.
+//
+// Since every block is computed independently, Seek moves the engine to any
+// block and Advance (prng.Advancer) skips any number of values in constant
+// time.
+//
+// References:
+//
+// D. J. Bernstein, "ChaCha, a variant of Salsa20", Workshop Record of
+// SASC 2008.
+//
+// Y. Nir and A. Langley, "ChaCha20 and Poly1305 for IETF Protocols",
+// RFC 8439 (2018).
+//
+// https://cr.yp.to/chacha.html
+// https://www.rfc-editor.org/rfc/rfc8439
+package chacha
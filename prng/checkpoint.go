@@ -0,0 +1,106 @@
+package prng
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+)
+
+// CheckpointVersion is the version of the checkpoint format written by the
+// GetState methods of the engines
+const CheckpointVersion uint16 = 1
+
+// checkpointMagic are the first bytes of a checkpoint. The first byte is not
+// ASCII, so that checkpoints are never mistaken for the states written
+// before version 1, which start with the name of the algorithm.
+var checkpointMagic = []byte{0x89, 'R', 'N', 'G'}
+
+// Checkpoint is the container of the states returned by GetState. In
+// little-endian byte order, a checkpoint consists of:
+//
+//	magic     [4]byte  0x89 'R' 'N' 'G'
+//	version   uint16   CheckpointVersion
+//	nameLen   uint8    length of the name of the algorithm
+//	name      [nameLen]byte
+//	length    uint32   length of the payload
+//	payload   [length]byte
+//	crc       uint32   CRC-32 (IEEE) of all the preceding bytes
+//
+// The payload holds the fields of the state of the engine, as written after
+// the name of the algorithm by GetState before version 1. States written
+// before version 1, i.e. the name of the algorithm followed by the payload,
+// are still accepted by SetState.
+type Checkpoint struct {
+	// Version is the version of the checkpoint format
+	Version uint16
+
+	// Algorithm is the name of the algorithm of the engine, e.g. "mt19937"
+	Algorithm string
+
+	// Payload is the state of the engine
+	Payload []byte
+}
+
+// IsCheckpoint reports whether b starts with the magic bytes of a checkpoint,
+// as opposed to a state written before version 1
+func IsCheckpoint(b []byte) bool {
+	return bytes.HasPrefix(b, checkpointMagic)
+}
+
+// EncodeCheckpoint returns the checkpoint of the state payload of an engine
+// implementing the algorithm algo, in the current version of the format
+func EncodeCheckpoint(algo string, payload []byte) []byte {
+	if len(algo) > 255 {
+		panic(fmt.Sprintf("prng: Algorithm name too long: '%s'", algo))
+	}
+	buf := new(bytes.Buffer)
+	buf.Write(checkpointMagic)
+	_ = binary.Write(buf, binary.LittleEndian, CheckpointVersion)
+	buf.WriteByte(byte(len(algo)))
+	buf.WriteString(algo)
+	_ = binary.Write(buf, binary.LittleEndian, uint32(len(payload)))
+	buf.Write(payload)
+	crc := crc32.ChecksumIEEE(buf.Bytes())
+	_ = binary.Write(buf, binary.LittleEndian, crc)
+	return buf.Bytes()
+}
+
+// DecodeCheckpoint decodes the checkpoint b, returning an error if b is not
+// a checkpoint, if it is truncated or oversized, if its version is not
+// supported or if its CRC does not match its content
+func DecodeCheckpoint(b []byte) (*Checkpoint, error) {
+	if !IsCheckpoint(b) {
+		return nil, fmt.Errorf("Not a checkpoint")
+	}
+	n := len(checkpointMagic)
+	if len(b) < n+3 {
+		return nil, fmt.Errorf("Unexpected end of checkpoint")
+	}
+	c := &Checkpoint{Version: binary.LittleEndian.Uint16(b[n:])}
+	if c.Version == 0 || c.Version > CheckpointVersion {
+		return nil, fmt.Errorf("Unsupported checkpoint version %d", c.Version)
+	}
+	nameLen := int(b[n+2])
+	n += 3
+	if len(b) < n+nameLen+4 {
+		return nil, fmt.Errorf("Unexpected end of checkpoint")
+	}
+	c.Algorithm = string(b[n : n+nameLen])
+	n += nameLen
+	length := uint64(binary.LittleEndian.Uint32(b[n:]))
+	n += 4
+	if uint64(len(b)) < uint64(n)+length+4 {
+		return nil, fmt.Errorf("Unexpected end of checkpoint")
+	}
+	if uint64(len(b)) > uint64(n)+length+4 {
+		return nil, fmt.Errorf("Unexpected %d bytes after checkpoint",
+			uint64(len(b))-uint64(n)-length-4)
+	}
+	c.Payload = b[n : n+int(length)]
+	n += int(length)
+	if crc := binary.LittleEndian.Uint32(b[n:]); crc != crc32.ChecksumIEEE(b[:n]) {
+		return nil, fmt.Errorf("Checksum mismatch")
+	}
+	return c, nil
+}
@@ -0,0 +1,123 @@
+package prng_test
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"testing"
+
+	"github.com/shivakar/random/prng"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Checkpoint_EncodeDecode(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, payload := range [][]byte{nil, {1}, []byte("Hello, World!")} {
+		b := prng.EncodeCheckpoint("test", payload)
+		assert.True(prng.IsCheckpoint(b))
+		assert.Equal(4+2+1+4+4+4+len(payload), len(b))
+		c, err := prng.DecodeCheckpoint(b)
+		if assert.NoError(err) {
+			assert.Equal(prng.CheckpointVersion, c.Version)
+			assert.Equal("test", c.Algorithm)
+			assert.Equal(len(payload), len(c.Payload))
+			assert.Equal(string(payload), string(c.Payload))
+		}
+	}
+
+	assert.Panics(func() {
+		_ = prng.EncodeCheckpoint(string(make([]byte, 256)), nil)
+	})
+}
+
+func Test_Checkpoint_Errors(t *testing.T) {
+	assert := assert.New(t)
+
+	b := prng.EncodeCheckpoint("test", []byte("Hello"))
+
+	// States written before version 1
+	assert.False(prng.IsCheckpoint([]byte("mt19937")))
+	_, err := prng.DecodeCheckpoint([]byte("mt19937"))
+	assert.Error(err)
+
+	// Truncated and oversized checkpoints
+	for n := 0; n < len(b); n++ {
+		_, err = prng.DecodeCheckpoint(b[:n])
+		assert.Error(err, "%d bytes", n)
+	}
+	_, err = prng.DecodeCheckpoint(append(b[:len(b):len(b)], 0))
+	assert.Error(err)
+
+	// Corrupted checkpoints
+	for i := range b {
+		c := append([]byte(nil), b...)
+		c[i] ^= 0x10
+		_, err = prng.DecodeCheckpoint(c)
+		assert.Error(err, "byte %d", i)
+	}
+
+	// Unsupported versions, with a valid checksum
+	for _, v := range []uint16{0, prng.CheckpointVersion + 1} {
+		c := append([]byte(nil), b[:len(b)-4]...)
+		binary.LittleEndian.PutUint16(c[4:], v)
+		c = append(c, 0, 0, 0, 0)
+		binary.LittleEndian.PutUint32(c[len(c)-4:], crc32.ChecksumIEEE(c[:len(c)-4]))
+		_, err = prng.DecodeCheckpoint(c)
+		if assert.Error(err) {
+			assert.Contains(err.Error(), "version")
+		}
+	}
+}
+
+func Test_Checkpoint_Engines(t *testing.T) {
+	assert := assert.New(t)
+
+	sources := restorers(20170612)
+	for algo, e := range restorers(1) {
+		src := sources[algo]
+		_ = src.Uint64()
+		c, err := prng.DecodeCheckpoint(src.GetState())
+		if !assert.NoError(err, algo) {
+			continue
+		}
+		assert.Equal(prng.CheckpointVersion, c.Version, algo)
+		assert.Equal(algo, c.Algorithm, algo)
+
+		// States written before version 1 are the name of the algorithm
+		// followed by the payload
+		legacy := append([]byte(algo), c.Payload...)
+		assert.NoError(e.(prng.StateRestorer).SetStateE(legacy), algo)
+		assert.Equal(src.GetState(), e.GetState(), algo)
+		e.Reset()
+		e.SetState(legacy)
+		assert.Equal(src.GetState(), e.GetState(), algo)
+		assert.Equal(src.Uint64(), e.Uint64(), algo)
+	}
+
+	// States of SplitMix64 written before its increment was part of the
+	// state use the default increment
+	e := restorers(1)["splitmix64"]
+	e.SetState(encode([]byte("splitmix64"), uint64(5), uint64(5)))
+	assert.Equal(restorers(5)["splitmix64"].GetState(), e.GetState())
+}
+
+func Test_Checkpoint_LegacyPrefix(t *testing.T) {
+	assert := assert.New(t)
+
+	// Names of algorithms that are a prefix of another one
+	pairs := [][2]string{
+		{"mt19937", "mt19937ar"},
+		{"pcg64", "pcg64dxsm"},
+		{"xoroshiro128plus", "xoroshiro128plusplus"},
+	}
+	sources := restorers(20170612)
+	for _, p := range pairs {
+		c, _ := prng.DecodeCheckpoint(sources[p[1]].GetState())
+		legacy := append([]byte(p[1]), c.Payload...)
+		err := sources[p[0]].(prng.StateRestorer).SetStateE(legacy)
+		if assert.Error(err, p[0]) {
+			assert.Equal(p[0]+": Error decoding state\nExpected '"+p[0]+
+				"', got '"+p[1]+"'", err.Error())
+		}
+	}
+}
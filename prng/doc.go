@@ -1,3 +1,25 @@
 // Package prng defines interfaces to be implemented by packages that
 // implement PRNG engines
+//
+// The packages implementing the engines register them by the name of their
+// algorithm, so that New and FromState can create an engine by name or from
+// a saved state without knowing its type, e.g.
+//
+//	import _ "github.com/shivakar/random/prng/mt19937"
+//
+//	e, err := prng.New("mt19937", 20170612)
+//	...
+//	e, err = prng.FromState(state)
+//
+// Besides GetState, the engines implement encoding.BinaryMarshaler,
+// encoding.TextMarshaler and json.Marshaler, and the corresponding
+// unmarshalers. The JSON encoding holds the name of the algorithm, the
+// version of the checkpoint format, the seed and the other fields of the
+// state, at the width of the engine's own words, e.g.
+//
+//	{"algorithm":"xoroshiro128plus","version":1,"seed":1,
+//	 "state":[10451216379200822465,13757245211066428519]}
+//
+// The words of 64-bit engines exceed 2^53, and JSON decoders that decode
+// numbers as float64, as in JavaScript, lose their precision.
 package prng
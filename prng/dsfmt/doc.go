@@ -0,0 +1,29 @@
+// Package dsfmt implements DSFMT19937, the double precision SIMD-oriented
+// Fast Mersenne Twister pseudo-random number generator with period
+// 2^19937-1.
+//
+// dSFMT generates float64 values in [1.0, 2.0) directly, by producing their
+// 52-bit mantissas. DSFMT19937 generates the same sequences as the
+// reference implementation for seeds less than 2^32 (dsfmt_init_gen_rand)
+// and for keys given to SeedArray (dsfmt_init_by_array):
+//
+//   - Float64Closed1Open2 matches dsfmt_genrand_close1_open2
+//   - Float64 matches dsfmt_genrand_close_open
+//   - Float64OO matches dsfmt_genrand_open_open
+//
+// Float64 and Float64OO thus have a resolution of 2^-52. Uint64 consumes
+// two words of the state, concatenating the 52 random bits of the first one
+// with the 12 most significant random bits of the second one.
+//
+// FillFloat64 and FillUint64 fill slices with the values of Float64 and
+// Uint64, reading whole blocks of the state, similarly to
+// dsfmt_fill_array_close_open.
+//
+// References:
+//
+// M. Saito and M. Matsumoto, "A PRNG Specialized in Double Precision
+// Floating Point Numbers Using an Affine Transition", Monte Carlo and
+// Quasi-Monte Carlo Methods 2008, Springer (2009) 589--602.
+//
+// http://www.math.sci.hiroshima-u.ac.jp/%7Em-mat/MT/SFMT/index.html
+package dsfmt
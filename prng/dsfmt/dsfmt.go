@@ -0,0 +1,424 @@
+package dsfmt
+
+import (
+	"encoding/gob"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+)
+
+var (
+	dsfmt *DSFMT19937
+	_     prng.Engine        = dsfmt
+	_     prng.StateRestorer = dsfmt
+)
+
+func init() {
+	prng.Register("dsfmt19937", func(seed uint64) prng.Engine { return New(seed) })
+	gob.Register(new(DSFMT19937))
+}
+
+// Parameters of dSFMT19937, from dSFMT-params19937.h
+const (
+	n    = 191 // number of 128-bit words of the state, without the lung
+	n64  = n * 2
+	pos1 = 117
+	sl1  = 19
+	sr   = 12
+
+	msk1 uint64 = 0x000ffafffffffb3f
+	msk2 uint64 = 0x000ffdfffc90fffd
+	fix1 uint64 = 0x90014964b32f4329
+	fix2 uint64 = 0x3b8d12ac548a7c7a
+	pcv1 uint64 = 0x3d84e1ac0dc82880
+	pcv2 uint64 = 0x0000000000000001
+
+	lowMask   uint64 = 0x000FFFFFFFFFFFFF
+	highConst uint64 = 0x3FF0000000000000
+)
+
+// DSFMT19937 implements the double precision SIMD-oriented Fast Mersenne
+// Twister with period 2^19937-1. Every 64-bit word of the state is a
+// float64 in [1.0, 2.0) with 52 random bits; the state is regenerated in
+// blocks of 191 128-bit words, i.e. 382 float64 values.
+type DSFMT19937 struct {
+	seed  uint64
+	key   []uint32
+	index int // index of the next 64-bit word in state
+	state [n + 1][2]uint64
+}
+
+// New returns a new instance of the DSFMT19937 PRNG Engine.
+// If the seed provided is 0, the engine is initialized with current time
+func New(seed uint64) *DSFMT19937 {
+	r := new(DSFMT19937)
+	r.Seed(seed)
+	return r
+}
+
+// NewWithArray returns a new instance of the DSFMT19937 PRNG Engine
+// initialized with the given key using dsfmt_init_by_array
+func NewWithArray(key []uint32) *DSFMT19937 {
+	r := new(DSFMT19937)
+	r.SeedArray(key)
+	return r
+}
+
+// generate computes the next block of the state. The last 128-bit word of
+// the state, the lung, is updated in every step of the recursion.
+func (r *DSFMT19937) generate() {
+	s := &r.state
+	l0, l1 := s[n][0], s[n][1]
+	step := func(i, j int) {
+		t0, t1 := s[i][0], s[i][1]
+		l0, l1 = (t0<<sl1)^(l1>>32)^(l1<<32)^s[j][0],
+			(t1<<sl1)^(l0>>32)^(l0<<32)^s[j][1]
+		s[i][0] = (l0 >> sr) ^ (l0 & msk1) ^ t0
+		s[i][1] = (l1 >> sr) ^ (l1 & msk2) ^ t1
+	}
+	i := 0
+	for ; i < n-pos1; i++ {
+		step(i, i+pos1)
+	}
+	for ; i < n; i++ {
+		step(i, i+pos1-n)
+	}
+	s[n][0], s[n][1] = l0, l1
+	r.index = 0
+}
+
+// next returns the next 64-bit word of the state, i.e. the bits of a
+// float64 in [1.0, 2.0)
+func (r *DSFMT19937) next() uint64 {
+	if r.index >= n64 {
+		r.generate()
+	}
+	v := r.state[r.index/2][r.index&1]
+	r.index++
+	return v
+}
+
+// Float64Closed1Open2 returns a pseudo-random number in [1.0, 2.0) as a
+// float64, the native output of the engine, i.e. the output of
+// dsfmt_genrand_close1_open2 of the reference implementation.
+// Float64Closed1Open2 advances the internal state of the engine.
+func (r *DSFMT19937) Float64Closed1Open2() float64 {
+	return math.Float64frombits(r.next())
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64,
+// made of the 52 random bits of a word of the state followed by the 12 most
+// significant random bits of the next word.
+// Uint64 advances the internal state of the engine by two words.
+func (r *DSFMT19937) Uint64() uint64 {
+	hi := r.next() & lowMask
+	lo := r.next() & lowMask
+	return hi<<12 | lo>>40
+}
+
+// FillUint64 fills dst with pseudo-random 64-bit values, i.e. it is
+// equivalent to, but faster than, calling Uint64 len(dst) times.
+func (r *DSFMT19937) FillUint64(dst []uint64) {
+	i := 0
+	for ; i < len(dst) && r.index&1 != 0; i++ {
+		dst[i] = r.Uint64()
+	}
+	for i < len(dst) {
+		if r.index >= n64 {
+			r.generate()
+		}
+		// Every 128-bit word of the state yields one value
+		k := (n64 - r.index) / 2
+		if k > len(dst)-i {
+			k = len(dst) - i
+		}
+		for _, w := range r.state[r.index/2 : r.index/2+k] {
+			dst[i] = (w[0]&lowMask)<<12 | (w[1]&lowMask)>>40
+			i++
+		}
+		r.index += 2 * k
+	}
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64, with
+// 52-bit resolution, i.e. the output of dsfmt_genrand_close_open of the
+// reference implementation.
+// Float64 advances the internal state of the engine.
+func (r *DSFMT19937) Float64() float64 {
+	return math.Float64frombits(r.next()) - 1.0
+}
+
+// FillFloat64 fills dst with pseudo-random numbers in [0.0, 1.0), i.e. it
+// is equivalent to, but faster than, calling Float64 len(dst) times.
+func (r *DSFMT19937) FillFloat64(dst []float64) {
+	for i := 0; i < len(dst); {
+		if r.index >= n64 {
+			r.generate()
+		}
+		k := n64 - r.index
+		if k > len(dst)-i {
+			k = len(dst) - i
+		}
+		for j := r.index; j < r.index+k; j++ {
+			dst[i] = math.Float64frombits(r.state[j/2][j&1]) - 1.0
+			i++
+		}
+		r.index += k
+	}
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64,
+// with 52-bit resolution, i.e. the output of dsfmt_genrand_open_open of the
+// reference implementation.
+// Float6400 advances the internal state of the engine.
+func (r *DSFMT19937) Float64OO() float64 {
+	return math.Float64frombits(r.next()|1) - 1.0
+}
+
+// Seed uses the provided value to initialize the engine. Seeds less than
+// 2^32 are used as in dsfmt_init_gen_rand. Larger seeds are split into their
+// least and most significant 32 bits and used as key of
+// dsfmt_init_by_array.
+// If the seed provided is 0, the engine is initialized with current time
+func (r *DSFMT19937) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	r.seed = seed
+	r.key = nil
+	if seed>>32 == 0 {
+		r.initGenRand(uint32(seed))
+		return
+	}
+	r.initByArray([]uint32{uint32(seed), uint32(seed >> 32)})
+}
+
+// SeedArray initializes the engine with the given key as in
+// dsfmt_init_by_array. GetSeed returns 0 after SeedArray. SeedArray panics
+// if the key is empty.
+func (r *DSFMT19937) SeedArray(key []uint32) {
+	if len(key) == 0 {
+		panic("dsfmt: Empty key")
+	}
+	r.seed = 0
+	r.key = append([]uint32(nil), key...)
+	r.initByArray(r.key)
+}
+
+// size is the number of 32-bit words of the state, including the lung
+const size = (n + 1) * 4
+
+// setWords sets the state from its 32-bit words, in the order of the
+// reference implementation on a little-endian machine, then masks the
+// state and certifies the period
+func (r *DSFMT19937) setWords(s *[size]uint32) {
+	for i := range r.state {
+		for j := range r.state[i] {
+			k := 4*i + 2*j
+			r.state[i][j] = uint64(s[k]) | uint64(s[k+1])<<32
+		}
+	}
+	// Set the exponent of the words that are float64 values
+	for i := 0; i < n; i++ {
+		for j := range r.state[i] {
+			r.state[i][j] = r.state[i][j]&lowMask | highConst
+		}
+	}
+	r.certifyPeriod()
+	r.index = n64
+}
+
+// initGenRand initializes the state using a 32-bit seed
+func (r *DSFMT19937) initGenRand(seed uint32) {
+	var s [size]uint32
+	s[0] = seed
+	for i := 1; i < size; i++ {
+		s[i] = 1812433253*(s[i-1]^(s[i-1]>>30)) + uint32(i)
+	}
+	r.setWords(&s)
+}
+
+// initByArray initializes the state using an array of 32-bit words
+func (r *DSFMT19937) initByArray(key []uint32) {
+	const lag = 11
+	const mid = (size - lag) / 2
+	func1 := func(x uint32) uint32 { return (x ^ (x >> 27)) * 1664525 }
+	func2 := func(x uint32) uint32 { return (x ^ (x >> 27)) * 1566083941 }
+
+	var s [size]uint32
+	for i := range s {
+		s[i] = 0x8b8b8b8b
+	}
+	count := size
+	if len(key)+1 > size {
+		count = len(key) + 1
+	}
+	rr := func1(s[0] ^ s[mid] ^ s[size-1])
+	s[mid] += rr
+	rr += uint32(len(key))
+	s[mid+lag] += rr
+	s[0] = rr
+	count--
+	i, j := 1, 0
+	for ; j < count; j++ {
+		rr = func1(s[i] ^ s[(i+mid)%size] ^ s[(i+size-1)%size])
+		s[(i+mid)%size] += rr
+		if j < len(key) {
+			rr += key[j]
+		}
+		rr += uint32(i)
+		s[(i+mid+lag)%size] += rr
+		s[i] = rr
+		i = (i + 1) % size
+	}
+	for j = 0; j < size; j++ {
+		rr = func2(s[i] + s[(i+mid)%size] + s[(i+size-1)%size])
+		s[(i+mid)%size] ^= rr
+		rr -= uint32(i)
+		s[(i+mid+lag)%size] ^= rr
+		s[i] = rr
+		i = (i + 1) % size
+	}
+	r.setWords(&s)
+}
+
+// certifyPeriod modifies the lung, if needed, so that the period of the
+// engine is 2^19937-1
+func (r *DSFMT19937) certifyPeriod() {
+	inner := (r.state[n][0] ^ fix1) & pcv1
+	inner ^= (r.state[n][1] ^ fix2) & pcv2
+	for i := 32; i > 0; i >>= 1 {
+		inner ^= inner >> uint(i)
+	}
+	if inner&1 == 1 {
+		return
+	}
+	// The least significant bit of pcv2 is set
+	r.state[n][1] ^= 1
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (r *DSFMT19937) GetSeed() uint64 { return r.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (r *DSFMT19937) GetState() []byte {
+	return state.Encode("dsfmt19937", r.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (r *DSFMT19937) fields() []interface{} {
+	return []interface{}{
+		uint64(r.seed),
+		uint64(len(r.key)),
+		r.key,
+		uint64(r.index),
+		r.state,
+	}
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (r *DSFMT19937) SetState(b []byte) {
+	if err := r.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of DSFMT19937, e.g. if it is truncated, if
+// its index is not in [0, 382] or if a word of its state is not the bit
+// pattern of a float64 in [1, 2)
+func (r *DSFMT19937) SetStateE(b []byte) error {
+	return r.setState(state.NewDecoder("dsfmt19937", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (r *DSFMT19937) setState(d *state.Decoder) error {
+	var seed, keyLen, index uint64
+	var key []uint32
+	var st [n + 1][2]uint64
+	d.Read(&seed, &keyLen)
+	if keyLen > uint64(d.Len())/4 {
+		d.Fail(fmt.Errorf("Key length %d exceeds the data", keyLen))
+	} else if keyLen > 0 {
+		key = make([]uint32, keyLen)
+	} else if seed == 0 {
+		d.Fail(fmt.Errorf("Expected a seed or a key"))
+	}
+	d.Read(key, &index, &st)
+	if index > uint64(n64) {
+		d.Fail(fmt.Errorf("Invalid index %d", index))
+	}
+	for i := 0; i < n; i++ {
+		for _, w := range st[i] {
+			if w&^lowMask != highConst {
+				d.Fail(fmt.Errorf("Invalid word %#x at %d", w, i))
+			}
+		}
+	}
+	if err := d.Err(); err != nil {
+		return err
+	}
+	r.seed = seed
+	r.key = key
+	r.index = int(index)
+	r.state = st
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed, or the key if the engine was initialized by SeedArray
+func (r *DSFMT19937) Reset() {
+	if r.key != nil {
+		r.initByArray(r.key)
+		return
+	}
+	r.Seed(r.seed)
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (r *DSFMT19937) MarshalBinary() ([]byte, error) { return r.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (r *DSFMT19937) UnmarshalBinary(b []byte) error { return r.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (r *DSFMT19937) MarshalText() ([]byte, error) {
+	return state.MarshalText(r.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (r *DSFMT19937) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("dsfmt19937", text)
+	if err != nil {
+		return err
+	}
+	return r.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (r *DSFMT19937) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("dsfmt19937", r.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (r *DSFMT19937) UnmarshalJSON(data []byte) error {
+	return r.setState(state.NewJSONDecoder("dsfmt19937", data))
+}
@@ -0,0 +1,235 @@
+package dsfmt_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/dsfmt"
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadir = filepath.Join("..", "..", "data", "dsfmt")
+var longTest bool
+
+// TestMain is the entry point for testing
+func TestMain(m *testing.M) {
+	longTest = prngtest.ParseCommandLine()
+	os.Exit(m.Run())
+}
+
+func Test_DSFMT19937_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+	seeds := []uint64{1, 5, 10, 1024, 200000, 6662751973}
+	r := dsfmt.New(0)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+	r.SeedArray([]uint32{1, 2, 3, 4})
+	assert.Zero(r.GetSeed())
+
+	assert.Panics(func() {
+		r.SeedArray(nil)
+	})
+}
+
+func Test_DSFMT19937_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed remains same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024, 6662751973}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := dsfmt.New(seed)
+		states[i] = r.GetState()
+	}
+
+	for i, state := range states {
+		r := dsfmt.New(0)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+	}
+
+	// Checking that the streams remain same after
+	// getting and setting states
+	r1 := dsfmt.New(0)
+	for i := 0; i < 11; i++ {
+		_ = r1.Float64()
+	}
+	r2 := dsfmt.New(0)
+	r2.SetState(r1.GetState())
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+		_ = r2.Uint64()
+	}
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	assert.Equal(r1.Float64(), r2.Float64())
+	assert.Equal(r1.Float64OO(), r2.Float64OO())
+	assert.Equal(r1.Float64Closed1Open2(), r2.Float64Closed1Open2())
+
+	// Checking that the key of SeedArray is restored, so that Reset
+	// reverts to the same stream
+	r1 = dsfmt.NewWithArray([]uint32{1, 2, 3, 4})
+	v := r1.Float64()
+	r2 = dsfmt.New(0)
+	r2.SetState(r1.GetState())
+	r2.Reset()
+	assert.Equal(v, r2.Float64())
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := dsfmt.New(0)
+		r1.SetState([]byte("Hello"))
+	})
+	assert.Panics(func() {
+		r1 := dsfmt.New(0)
+		r1.SetState(nil)
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("dsfmt19937"))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := dsfmt.New(0)
+		r1.SetState(buf.Bytes())
+	})
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("dsfmt19937"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(1000))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := dsfmt.New(0)
+		r1.SetState(buf.Bytes())
+	})
+
+	// Index beyond the end of the state, neither seed nor key, and a word
+	// of the state that is not a float64 in [1.0, 2.0)
+	for _, v := range [][3]uint64{{10, 383, 0}, {0, 0, 0}, {10, 0, 1}} {
+		var state [192][2]uint64
+		for i := range state {
+			state[i] = [2]uint64{0x3ff0000000000000, 0x3ff0000000000000}
+		}
+		state[5][1] ^= v[2] << 62
+		buf = new(bytes.Buffer)
+		_ = binary.Write(buf, binary.LittleEndian, []byte("dsfmt19937"))
+		_ = binary.Write(buf, binary.LittleEndian, v[0])
+		_ = binary.Write(buf, binary.LittleEndian, uint64(0))
+		_ = binary.Write(buf, binary.LittleEndian, v[1])
+		_ = binary.Write(buf, binary.LittleEndian, state)
+		assert.Panics(func() {
+			r1 := dsfmt.New(0)
+			r1.SetState(buf.Bytes())
+		})
+	}
+}
+
+func Test_DSFMT19937_Uint64(t *testing.T) {
+	e := dsfmt.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "dsfmt19937-*-uint64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_DSFMT19937_Float64(t *testing.T) {
+	e := dsfmt.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "dsfmt19937-*-float64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_DSFMT19937_Float64OO(t *testing.T) {
+	e := dsfmt.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "dsfmt19937-*-float64oo-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_DSFMT19937_Fill(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking that FillUint64 and FillFloat64 match Uint64 and Float64 for
+	// various lengths and positions in the state, including odd numbers of
+	// words drawn
+	lengths := []int{0, 1, 190, 191, 192, 382, 1000, 5000}
+	for _, skip := range []int{0, 1, 2, 381, 382} {
+		for _, l := range lengths {
+			r1 := dsfmt.New(20170612)
+			r2 := dsfmt.New(20170612)
+			for i := 0; i < skip; i++ {
+				_ = r1.Float64()
+				_ = r2.Float64()
+			}
+			dst := make([]uint64, l)
+			r1.FillUint64(dst)
+			for _, v := range dst {
+				assert.Equal(r2.Uint64(), v)
+			}
+			fdst := make([]float64, l)
+			r1.FillFloat64(fdst)
+			for _, v := range fdst {
+				assert.Equal(r2.Float64(), v)
+			}
+			assert.Equal(r2.Float64(), r1.Float64())
+		}
+	}
+}
+
+// Benchmarks
+func Benchmark_DSFMT19937_Uint64(b *testing.B) {
+	rng := dsfmt.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_DSFMT19937_FillUint64(b *testing.B) {
+	rng := dsfmt.New(0)
+	dst := make([]uint64, 1<<16)
+	b.SetBytes(8 * int64(len(dst)))
+	for i := 0; i < b.N; i++ {
+		rng.FillUint64(dst)
+	}
+}
+
+func Benchmark_DSFMT19937_Float64(b *testing.B) {
+	rng := dsfmt.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_DSFMT19937_FillFloat64(b *testing.B) {
+	rng := dsfmt.New(0)
+	dst := make([]float64, 1<<16)
+	b.SetBytes(8 * int64(len(dst)))
+	for i := 0; i < b.N; i++ {
+		rng.FillFloat64(dst)
+	}
+}
+
+func Benchmark_DSFMT19937_Float64OO(b *testing.B) {
+	rng := dsfmt.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+// Example - Bulk generation of float64 values with DSFMT19937
+func ExampleDSFMT19937_FillFloat64() {
+	r := dsfmt.New(20170612)
+
+	// Fill a slice with values in [0.0, 1.0), as dsfmt_fill_array_close_open
+	// does
+	values := make([]float64, 1000)
+	r.FillFloat64(values)
+	fmt.Println(values[0], values[1], values[999])
+
+	// Output:
+	// 0.21244331112523906 0.5006889035476323 0.15666638565544821
+}
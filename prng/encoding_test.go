@@ -0,0 +1,183 @@
+package prng_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/splitmix64"
+	"github.com/shivakar/random/prng/well"
+	"github.com/shivakar/random/prng/xoroshiro128plus"
+	"github.com/stretchr/testify/assert"
+)
+
+// marshaler is implemented by all engines
+type marshaler interface {
+	prng.Engine
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+	json.Marshaler
+	json.Unmarshaler
+}
+
+func Test_Encoding_Binary(t *testing.T) {
+	assert := assert.New(t)
+
+	for name, src := range restorers(20170612) {
+		_ = src.Uint64()
+		m, ok := src.(marshaler)
+		if !assert.True(ok, name) {
+			continue
+		}
+		b, err := m.MarshalBinary()
+		assert.NoError(err, name)
+		assert.Equal(src.GetState(), b, name)
+		e, _ := prng.New(name, 1)
+		assert.NoError(e.(marshaler).UnmarshalBinary(b), name)
+		assert.Equal(src.GetState(), e.GetState(), name)
+		assert.Error(e.(marshaler).UnmarshalBinary(b[1:]), name)
+	}
+}
+
+func Test_Encoding_Text(t *testing.T) {
+	assert := assert.New(t)
+
+	for name, src := range restorers(20170612) {
+		_ = src.Uint64()
+		text, err := src.(marshaler).MarshalText()
+		assert.NoError(err, name)
+		e, _ := prng.New(name, 1)
+		assert.NoError(e.(marshaler).UnmarshalText(text), name)
+		assert.Equal(src.GetState(), e.GetState(), name)
+		assert.Error(e.(marshaler).UnmarshalText([]byte("Hello!")), name)
+		assert.Error(e.(marshaler).UnmarshalText(text[4:]), name)
+		assert.Equal(src.GetState(), e.GetState(), name)
+	}
+}
+
+func Test_Encoding_JSON(t *testing.T) {
+	assert := assert.New(t)
+
+	for name, src := range restorers(20170612) {
+		_ = src.Uint64()
+		data, err := json.Marshal(src)
+		if !assert.NoError(err, name) {
+			continue
+		}
+		var fields map[string]interface{}
+		assert.NoError(json.Unmarshal(data, &fields), name)
+		assert.Equal(name, fields["algorithm"], name)
+		assert.Equal(float64(20170612), fields["seed"], name)
+
+		e, _ := prng.New(name, 1)
+		assert.NoError(json.Unmarshal(data, e), name)
+		assert.Equal(src.GetState(), e.GetState(), name)
+
+		// Zero value of the engine, as created by encoding/json or gob
+		z := reflect.New(reflect.TypeOf(src).Elem()).Interface().(prng.Engine)
+		assert.NoError(json.Unmarshal(data, z), name)
+		assert.Equal(src.GetState(), z.GetState(), name)
+		v := src.Uint64()
+		assert.Equal(v, e.Uint64(), name)
+		assert.Equal(v, z.Uint64(), name)
+	}
+
+	s := splitmix64.New(1)
+	data, err := json.Marshal(s)
+	assert.NoError(err)
+	assert.Equal(`{"algorithm":"splitmix64","version":1,"seed":1,`+
+		`"state":[1,11400714819323198485]}`, string(data))
+
+	// The version may be omitted
+	assert.NoError(json.Unmarshal([]byte(`{"algorithm":"splitmix64",`+
+		`"seed":5,"state":[6,11400714819323198485]}`), s))
+	assert.Equal(uint64(5), s.GetSeed())
+	s.Reset()
+	assert.Equal(splitmix64.New(5).Uint64(), s.Uint64())
+
+	// Invalid encodings
+	for _, data := range []string{
+		`"splitmix64"`,
+		`{"algorithm":"splitmix64","seed":1,"state":[]}`,
+		`{"algorithm":"splitmix64","seed":1,"state":[1,2]}`,
+		`{"algorithm":"splitmix64","seed":1,"state":[1,11400714819323198485,1]}`,
+		`{"algorithm":"splitmix64","seed":1,"state":[1,-1]}`,
+		`{"algorithm":"splitmix64","version":2,"seed":1,` +
+			`"state":[1,11400714819323198485]}`,
+		`{"algorithm":"xorshift128plus","seed":1,"state":[1,1]}`,
+	} {
+		assert.Error(json.Unmarshal([]byte(data), s), data)
+	}
+}
+
+func Test_Encoding_JSON_Words(t *testing.T) {
+	assert := assert.New(t)
+
+	// The state of xoroshiro128plus is its two 64-bit words
+	x := xoroshiro128plus.New(1)
+	data, err := json.Marshal(x)
+	assert.NoError(err)
+	assert.Equal(`{"algorithm":"xoroshiro128plus","version":1,"seed":1,`+
+		`"state":[10451216379200822465,13757245211066428519]}`, string(data))
+	_ = x.Uint64()
+	assert.NoError(json.Unmarshal(data, x))
+	assert.Equal(xoroshiro128plus.New(1).Uint64(), x.Uint64())
+
+	// The state of well512a is its index followed by its 32-bit words, which
+	// must not overflow 32 bits
+	w := well.New512a(1)
+	data, err = json.Marshal(w)
+	assert.NoError(err)
+	var fields struct {
+		State []uint64 `json:"state"`
+	}
+	assert.NoError(json.Unmarshal(data, &fields))
+	assert.Len(fields.State, 17)
+	assert.Zero(fields.State[0])
+	bad := bytes.Replace(data, []byte(fmt.Sprintf("[0,%d,", fields.State[1])),
+		[]byte(fmt.Sprintf("[0,%d,", fields.State[1]|1<<32)), 1)
+	assert.NotEqual(data, bad)
+	assert.Error(json.Unmarshal(bad, w))
+	assert.Equal(well.New512a(1).GetState(), w.GetState())
+}
+
+func Test_Encoding_Gob(t *testing.T) {
+	assert := assert.New(t)
+
+	// Engines embedded in a larger state, including as a prng.Engine
+	type simulation struct {
+		Step    int
+		Engine  prng.Engine
+		Engines map[string]prng.Engine
+		SM      *splitmix64.SplitMix64
+	}
+	src := simulation{
+		Step:    10,
+		Engine:  restorers(5)["mt19937"],
+		Engines: restorers(20170612),
+		SM:      splitmix64.New(7),
+	}
+	buf := new(bytes.Buffer)
+	if !assert.NoError(gob.NewEncoder(buf).Encode(src)) {
+		return
+	}
+	var dst simulation
+	if !assert.NoError(gob.NewDecoder(buf).Decode(&dst)) {
+		return
+	}
+	assert.Equal(10, dst.Step)
+	assert.IsType(src.Engine, dst.Engine)
+	assert.Equal(src.Engine.GetState(), dst.Engine.GetState())
+	for name, e := range src.Engines {
+		assert.IsType(e, dst.Engines[name], name)
+		assert.Equal(e.GetState(), dst.Engines[name].GetState(), name)
+	}
+	assert.Equal(src.SM.GetState(), dst.SM.GetState())
+}
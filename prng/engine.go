@@ -23,6 +23,7 @@ type Engine interface {
 
 	// GetState returns the internal state of the engine as []byte
 	// GetState can be used to save the state, e.g. to a file
+	// The state is encoded as a Checkpoint.
 	GetState() []byte
 
 	// SetState sets the internal state of the engine from a []byte
@@ -33,3 +34,62 @@ type Engine interface {
 	// except the seed
 	Reset()
 }
+
+// Jumper is an optional interface implemented by engines that can advance
+// their internal state by a large, fixed number of steps in constant time.
+// Calling Jump repeatedly on copies of the same engine yields
+// non-overlapping sub-sequences suitable for parallel computations.
+type Jumper interface {
+	// Jump advances the internal state of the engine by a fixed number of
+	// steps specific to the engine, e.g. 2^64 steps for xoroshiro128plus.
+	Jump()
+}
+
+// LongJumper is an optional interface implemented by engines that, in
+// addition to Jump, support an even longer fixed-distance jump. It can be
+// used to generate starting points from which Jump can be called for
+// distributed computations.
+type LongJumper interface {
+	Jumper
+
+	// LongJump advances the internal state of the engine by a fixed number
+	// of steps larger than that of Jump, e.g. 2^96 steps for
+	// xoroshiro128plus.
+	LongJump()
+}
+
+// Advancer is an optional interface implemented by engines that can skip
+// ahead in their output stream faster than by drawing from the engine.
+// Engines implementing Advancer must draw exactly one Uint64 in each call of
+// Float64 and Float64OO, so that Advance also skips n of their values.
+type Advancer interface {
+	// Advance advances the internal state of the engine by n steps, i.e.
+	// as if Uint64 had been called n times.
+	Advance(n uint64)
+}
+
+// Splitter is an optional interface implemented by engines that can split
+// off new engines, in the style of Java's SplittableRandom. Split can be
+// called repeatedly, including on the engines it returns, to obtain a tree
+// of engines for parallel computations that is fully determined by the seed
+// of the root engine.
+type Splitter interface {
+	// Split returns a new engine that is deterministically derived from,
+	// and statistically independent of, this engine.
+	// Split advances the internal state of the engine.
+	Split() Engine
+}
+
+// StateRestorer is an optional interface implemented by engines that can
+// restore their internal state with full validation, e.g. to resume from a
+// checkpoint file that may have been truncated or corrupted.
+type StateRestorer interface {
+	// SetStateE sets the internal state of the engine from a []byte, as
+	// SetState, but returns an error instead of panicking if the []byte is
+	// not a state of the engine: if it does not start with the name of the
+	// algorithm, if it is shorter or longer than a state, or if it holds a
+	// state the engine cannot be in, e.g. an all-zero xorshift state.
+	// The internal state of the engine is unchanged if an error is
+	// returned.
+	SetStateE([]byte) error
+}
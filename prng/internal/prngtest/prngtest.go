@@ -28,6 +28,10 @@ type fileinfo struct {
 // parse parses metadata for a PRNG draws file from its filename
 // filename is expected to be in the following format
 // engine-seed-functionToCall-startIndex-numSamples.txt
+//
+// functionToCall "jump" and "longjump" call Jump or LongJump on the engine
+// right after seeding and then compare Uint64 draws. functionToCall
+// "uint32" compares draws of engines with a native Uint32 method.
 func (f *fileinfo) parse(filename string) {
 	var err error
 	bn := filepath.Base(filename)
@@ -57,7 +61,18 @@ func GetDataFiles(dataDir string, pattern string) []string {
 	return filenames
 }
 
-// CompareDraws compares output of an Engine against expected output
+// uint32Engine is implemented by engines generating 32-bit values natively
+type uint32Engine interface {
+	prng.Engine
+	Uint32() uint32
+}
+
+// CompareDraws compares output of an Engine against expected output.
+// Engines implementing prng.Advancer skip to the start index of a draws file
+// using Advance, and therefore also run the draws files that otherwise
+// require the long test. Advance counts Uint64 draws, which prng.Advancer
+// requires to also be the number of Float64 and Float64OO draws, so draws
+// files of Uint32 are always skipped to by drawing.
 func CompareDraws(t *testing.T, e prng.Engine, datafiles []string, longTest bool) {
 	assert := assert.New(t)
 	assert.NotZero(len(datafiles))
@@ -66,17 +81,43 @@ func CompareDraws(t *testing.T, e prng.Engine, datafiles []string, longTest bool
 		finfo.parse(filename)
 		e.Reset()
 		e.Seed(finfo.seed)
-		if !longTest && finfo.start >= 1e9 {
+		advancer, canAdvance := e.(prng.Advancer)
+		canAdvance = canAdvance && finfo.function != "uint32"
+		if !longTest && !canAdvance && finfo.start >= 1e9 {
 			continue
 		}
-		for i := uint64(0); i < finfo.start; i++ {
-			switch finfo.function {
-			case "uint64":
-				_ = e.Uint64()
-			case "float64":
-				_ = e.Float64()
-			case "float64oo":
-				_ = e.Float64OO()
+		switch finfo.function {
+		case "jump":
+			j, ok := e.(prng.Jumper)
+			if !assert.True(ok, "engine does not implement prng.Jumper") {
+				return
+			}
+			j.Jump()
+		case "longjump":
+			j, ok := e.(prng.LongJumper)
+			if !assert.True(ok, "engine does not implement prng.LongJumper") {
+				return
+			}
+			j.LongJump()
+		case "uint32":
+			if _, ok := e.(uint32Engine); !assert.True(ok, "engine does not implement Uint32") {
+				return
+			}
+		}
+		if canAdvance {
+			advancer.Advance(finfo.start)
+		} else {
+			for i := uint64(0); i < finfo.start; i++ {
+				switch finfo.function {
+				case "uint64", "jump", "longjump":
+					_ = e.Uint64()
+				case "float64":
+					_ = e.Float64()
+				case "float64oo":
+					_ = e.Float64OO()
+				case "uint32":
+					_ = e.(uint32Engine).Uint32()
+				}
 			}
 		}
 
@@ -89,7 +130,7 @@ func CompareDraws(t *testing.T, e prng.Engine, datafiles []string, longTest bool
 		s := bufio.NewScanner(file)
 		for s.Scan() {
 			switch finfo.function {
-			case "uint64":
+			case "uint64", "jump", "longjump":
 				v, _ := strconv.ParseUint(s.Text(), 10, 64)
 				assert.Equal(v, e.Uint64())
 			case "float64":
@@ -98,11 +139,37 @@ func CompareDraws(t *testing.T, e prng.Engine, datafiles []string, longTest bool
 			case "float64oo":
 				v, _ := strconv.ParseFloat(s.Text(), 64)
 				assert.InDelta(v, e.Float64OO(), float64(1e-15))
+			case "uint32":
+				v, _ := strconv.ParseUint(s.Text(), 10, 32)
+				assert.Equal(uint32(v), e.(uint32Engine).Uint32())
 			}
 		}
 	}
 }
 
+// CheckSplit checks that Split of an engine implementing prng.Splitter is
+// deterministic, i.e. that the engines it returns from the same state of s
+// are identical and leave s in the same state, and that they differ from s
+func CheckSplit(t *testing.T, s prng.Splitter) {
+	assert := assert.New(t)
+	e, ok := s.(prng.Engine)
+	if !assert.True(ok, "splitter does not implement prng.Engine") {
+		return
+	}
+	state := e.GetState()
+	c1 := s.Split()
+	parent := e.GetState()
+	e.SetState(state)
+	c2 := s.Split()
+	assert.Equal(parent, e.GetState())
+	assert.Equal(c1.GetState(), c2.GetState())
+	for i := 0; i < 10; i++ {
+		v := c1.Uint64()
+		assert.Equal(v, c2.Uint64())
+		assert.NotEqual(v, e.Uint64())
+	}
+}
+
 // ParseCommandLine parses command line arguments and returns relevant values
 func ParseCommandLine() (longTest bool) {
 	flag.BoolVar(&longTest, "long", false, "Include long running tests")
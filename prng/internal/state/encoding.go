@@ -0,0 +1,105 @@
+package state
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/shivakar/random/prng"
+)
+
+// jsonState is the JSON encoding of a state: the seed is the first field
+// of the states of all engines, and the other fields are flattened to one
+// word per integer
+type jsonState struct {
+	Algorithm string   `json:"algorithm"`
+	Version   uint16   `json:"version"`
+	Seed      uint64   `json:"seed"`
+	State     []uint64 `json:"state"`
+}
+
+// MarshalText returns the base64 encoding of the state b returned by
+// GetState
+func MarshalText(b []byte) ([]byte, error) {
+	text := make([]byte, base64.StdEncoding.EncodedLen(len(b)))
+	base64.StdEncoding.Encode(text, b)
+	return text, nil
+}
+
+// UnmarshalText returns the state of the algorithm algo encoded in text by
+// MarshalText
+func UnmarshalText(algo string, text []byte) ([]byte, error) {
+	b := make([]byte, base64.StdEncoding.DecodedLen(len(text)))
+	n, err := base64.StdEncoding.Decode(b, text)
+	if err != nil {
+		return nil, &prng.StateError{Algorithm: algo, Err: err}
+	}
+	return b[:n], nil
+}
+
+// MarshalJSON returns the JSON encoding of the state of the algorithm algo
+// made of the fields, as passed to Encode, whose first field is the seed:
+// the name of the algorithm, the version of the checkpoint format, the seed
+// and the other fields as JSON numbers, one per integer of the fields
+// including those of arrays and slices, e.g. the two 64-bit state words of
+// xoroshiro128plus:
+//
+//	{"algorithm":"xoroshiro128plus","version":1,"seed":1,
+//	 "state":[10451216379200822465,13757245211066428519]}
+func MarshalJSON(algo string, fields ...interface{}) ([]byte, error) {
+	var words []uint64
+	for _, v := range fields {
+		var err error
+		if words, err = appendWords(words, reflect.Indirect(reflect.ValueOf(v))); err != nil {
+			return nil, &prng.StateError{Algorithm: algo, Err: err}
+		}
+	}
+	if len(words) == 0 {
+		err := fmt.Errorf("Missing seed")
+		return nil, &prng.StateError{Algorithm: algo, Err: err}
+	}
+	return json.Marshal(jsonState{
+		Algorithm: algo,
+		Version:   prng.CheckpointVersion,
+		Seed:      words[0],
+		State:     words[1:],
+	})
+}
+
+// appendWords appends the integers of v to words
+func appendWords(words []uint64, v reflect.Value) ([]uint64, error) {
+	switch v.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return append(words, v.Uint()), nil
+	case reflect.Array, reflect.Slice:
+		var err error
+		for i := 0; i < v.Len() && err == nil; i++ {
+			words, err = appendWords(words, v.Index(i))
+		}
+		return words, err
+	}
+	return nil, fmt.Errorf("Unsupported field of type %s", v.Type())
+}
+
+// NewJSONDecoder returns a Decoder of the state of the algorithm algo
+// encoded in data by MarshalJSON, which reads the fields of the state from
+// its words. The version may be omitted from data.
+func NewJSONDecoder(algo string, data []byte) *Decoder {
+	d := &Decoder{algo: algo, checkpoint: true, json: true}
+	var s jsonState
+	if err := json.Unmarshal(data, &s); err != nil {
+		d.Fail(err)
+		return d
+	}
+	if s.Algorithm != algo {
+		d.Fail(fmt.Errorf("Expected '%s', got '%s'", algo, s.Algorithm))
+		return d
+	}
+	if s.Version != 0 && s.Version != prng.CheckpointVersion {
+		d.Fail(fmt.Errorf("Unsupported checkpoint version %d", s.Version))
+		return d
+	}
+	d.words = append([]uint64{s.Seed}, s.State...)
+	return d
+}
@@ -0,0 +1,158 @@
+// Package state implements the encoding, decoding and validation of the
+// states of the engines, for GetState, SetState and SetStateE, and their
+// text and JSON encodings
+package state
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/shivakar/random/prng"
+)
+
+// Decoder decodes the fields of the payload of a state of an algorithm.
+// The first error encountered is kept and returned by Err, and later calls
+// to Read and Fail have no effect.
+type Decoder struct {
+	algo       string
+	b          []byte
+	checkpoint bool
+	buf        *bytes.Reader
+	words      []uint64
+	json       bool
+	err        error
+}
+
+// NewDecoder returns a Decoder of the state b of the algorithm algo, which
+// may be either a checkpoint (prng.Checkpoint) or a state written before
+// version 1 of the checkpoint format, i.e. algo followed by the payload
+func NewDecoder(algo string, b []byte) *Decoder {
+	d := &Decoder{algo: algo, b: b}
+	if prng.IsCheckpoint(b) {
+		d.checkpoint = true
+		c, err := prng.DecodeCheckpoint(b)
+		if err != nil {
+			d.Fail(err)
+			return d
+		}
+		if c.Algorithm != algo {
+			d.Fail(fmt.Errorf("Expected '%s', got '%s'", algo, c.Algorithm))
+			return d
+		}
+		d.buf = bytes.NewReader(c.Payload)
+		return d
+	}
+	if !bytes.HasPrefix(b, []byte(algo)) {
+		n := len(algo)
+		if len(b) < n {
+			n = len(b)
+		}
+		d.Fail(fmt.Errorf("Expected '%s', got '%s'", algo, string(b[:n])))
+		return d
+	}
+	d.buf = bytes.NewReader(b[len(algo):])
+	return d
+}
+
+// Read decodes the next fields of the state, in little-endian byte order,
+// or from the next words of a JSON state, one word per integer of the fields
+func (d *Decoder) Read(fields ...interface{}) {
+	for _, v := range fields {
+		if d.err != nil {
+			return
+		}
+		if d.json {
+			d.readWords(reflect.Indirect(reflect.ValueOf(v)))
+			continue
+		}
+		if err := binary.Read(d.buf, binary.LittleEndian, v); err != nil {
+			d.err = fmt.Errorf("Unexpected end of state")
+		}
+	}
+}
+
+// readWords sets the integers of v to the next words of a JSON state
+func (d *Decoder) readWords(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if len(d.words) == 0 {
+			d.Fail(fmt.Errorf("Unexpected end of state"))
+			return
+		}
+		if v.OverflowUint(d.words[0]) {
+			d.Fail(fmt.Errorf("Word %d overflows %s", d.words[0], v.Type()))
+			return
+		}
+		v.SetUint(d.words[0])
+		d.words = d.words[1:]
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < v.Len() && d.err == nil; i++ {
+			d.readWords(v.Index(i))
+		}
+	default:
+		d.Fail(fmt.Errorf("Unsupported field of type %s", v.Type()))
+	}
+}
+
+// Len returns the number of bytes of the state not yet decoded. In a JSON
+// state, whose words are not all 8 bytes long, it is 8 times the number of
+// words not yet decoded, and thus an upper bound on the number of bytes.
+func (d *Decoder) Len() int {
+	if d.json {
+		return 8 * len(d.words)
+	}
+	if d.buf == nil {
+		return 0
+	}
+	return d.buf.Len()
+}
+
+// Fail records err as the reason why the state is invalid
+func (d *Decoder) Fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+// Err returns nil if the whole state was decoded without error, and a
+// *prng.StateError otherwise.
+// In states written before version 1 of the checkpoint format, a name of
+// algorithm may be a prefix of another one, e.g. "mt19937" of "mt19937ar",
+// but the states of two such algorithms always differ in length. A state of
+// the longer name is thus never decoded as a state of the shorter one, and
+// is reported as such if the longer name is registered with prng.
+func (d *Decoder) Err() error {
+	if d.err == nil && d.json && len(d.words) != 0 {
+		d.err = fmt.Errorf("Unexpected %d words after state", len(d.words))
+	}
+	if d.err == nil && d.Len() != 0 {
+		d.err = fmt.Errorf("Unexpected %d bytes after state", d.buf.Len())
+	}
+	if d.err == nil {
+		return nil
+	}
+	err := d.err
+	for _, t := range prng.Algorithms() {
+		if !d.checkpoint && len(t) > len(d.algo) && strings.HasPrefix(t, d.algo) &&
+			bytes.HasPrefix(d.b, []byte(t)) {
+			err = fmt.Errorf("Expected '%s', got '%s'", d.algo, t)
+		}
+	}
+	return &prng.StateError{Algorithm: d.algo, Err: err}
+}
+
+// Encode returns the checkpoint (prng.Checkpoint) of the state of the
+// algorithm algo made of the fields, in little-endian byte order
+func Encode(algo string, fields ...interface{}) []byte {
+	buf := new(bytes.Buffer)
+	for _, v := range fields {
+		if err := binary.Write(buf, binary.LittleEndian, v); err != nil {
+			msg := algo + ": Error encoding state"
+			panic(strings.Join([]string{msg, err.Error()}, "\n"))
+		}
+	}
+	return prng.EncodeCheckpoint(algo, buf.Bytes())
+}
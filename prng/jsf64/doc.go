@@ -0,0 +1,15 @@
+// Package jsf64 implements JSF64, the 64-bit variant of the small
+// noncryptographic pseudo-random number generator of Bob Jenkins, also
+// known as Jenkins Small Fast.
+//
+// JSF64 mixes four 64-bit words with additions, xors and rotations. It has
+// no guaranteed period, but the seeding of the reference implementation,
+// used by Seed, has no known short cycles. It passes BigCrush and
+// PractRand. It is not suitable for cryptographic use and, being
+// non-linear, it does not support jump-ahead.
+//
+// References:
+//
+// B. Jenkins, "A small noncryptographic PRNG",
+// http://burtleburtle.net/bob/rand/smallprng.html
+package jsf64
@@ -0,0 +1,178 @@
+package jsf64
+
+import (
+	"encoding/gob"
+	"fmt"
+	"math/bits"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+var (
+	jsf64 *JSF64
+	_     prng.Engine        = jsf64
+	_     prng.StateRestorer = jsf64
+)
+
+func init() {
+	prng.Register("jsf64", func(seed uint64) prng.Engine { return New(seed) })
+	gob.Register(new(JSF64))
+}
+
+// JSF64 implements the 64-bit small noncryptographic PRNG of Bob Jenkins,
+// also known as Jenkins Small Fast, with 256 bits of state
+type JSF64 struct {
+	seed       uint64
+	a, b, c, d uint64
+}
+
+// New returns a new instance of the JSF64 PRNG Engine.
+// If the seed provided is 0, the engine is initialized with current time
+func New(seed uint64) *JSF64 {
+	r := new(JSF64)
+	r.Seed(seed)
+	return r
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64.
+// Uint64 advances the internal state of the engine.
+func (j *JSF64) Uint64() uint64 {
+	e := j.a - bits.RotateLeft64(j.b, 7)
+	j.a = j.b ^ bits.RotateLeft64(j.c, 13)
+	j.b = j.c + bits.RotateLeft64(j.d, 37)
+	j.c = j.d + e
+	j.d = e + j.a
+	return j.d
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (j *JSF64) Float64() float64 {
+	return float64(j.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (j *JSF64) Float64OO() float64 {
+	return (float64(j.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine, as raninit of the
+// reference implementation does with the first output of SplitMix64
+// seeded with seed: a is set to 0xf1ea5eed, b, c and d to that output, and
+// the first 20 outputs are discarded.
+// If the seed provided is 0, the engine is initialized with current time
+func (j *JSF64) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	j.seed = seed
+	v := splitmix64.New(seed).Uint64()
+	j.a = 0xf1ea5eed
+	j.b, j.c, j.d = v, v, v
+	for i := 0; i < 20; i++ {
+		_ = j.Uint64()
+	}
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (j *JSF64) GetSeed() uint64 { return j.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (j *JSF64) GetState() []byte {
+	return state.Encode("jsf64", j.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (j *JSF64) fields() []interface{} {
+	return []interface{}{
+		uint64(j.seed),
+		uint64(j.a),
+		uint64(j.b),
+		uint64(j.c),
+		uint64(j.d),
+	}
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (j *JSF64) SetState(b []byte) {
+	if err := j.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of JSF64, e.g. if it is truncated or if its
+// state is all zero
+func (j *JSF64) SetStateE(b []byte) error {
+	return j.setState(state.NewDecoder("jsf64", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (j *JSF64) setState(d *state.Decoder) error {
+	var st [5]uint64
+	d.Read(&st)
+	if st[1]|st[2]|st[3]|st[4] == 0 {
+		d.Fail(fmt.Errorf("State must not be all zero"))
+	}
+	if err := d.Err(); err != nil {
+		return err
+	}
+	j.seed = st[0]
+	j.a, j.b, j.c, j.d = st[1], st[2], st[3], st[4]
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed
+func (j *JSF64) Reset() {
+	j.Seed(j.seed)
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (j *JSF64) MarshalBinary() ([]byte, error) { return j.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (j *JSF64) UnmarshalBinary(b []byte) error { return j.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (j *JSF64) MarshalText() ([]byte, error) {
+	return state.MarshalText(j.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (j *JSF64) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("jsf64", text)
+	if err != nil {
+		return err
+	}
+	return j.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (j *JSF64) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("jsf64", j.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (j *JSF64) UnmarshalJSON(data []byte) error {
+	return j.setState(state.NewJSONDecoder("jsf64", data))
+}
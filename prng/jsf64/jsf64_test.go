@@ -0,0 +1,147 @@
+package jsf64_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/jsf64"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadir = filepath.Join("..", "..", "data", "jsf64")
+var longTest bool
+
+// TestMain is the entry point for testing
+func TestMain(m *testing.M) {
+	longTest = prngtest.ParseCommandLine()
+	os.Exit(m.Run())
+}
+
+func Test_JSF64_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := jsf64.New(0)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+}
+
+func Test_JSF64_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed remains same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := jsf64.New(seed)
+		states[i] = r.GetState()
+	}
+	for i, state := range states {
+		r := jsf64.New(0)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+	}
+
+	// Checking that the streams remain same after getting and setting states
+	r1 := jsf64.New(0)
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+	}
+	r2 := jsf64.New(0)
+	r2.SetState(r1.GetState())
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+		_ = r2.Uint64()
+	}
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	assert.Equal(r1.Float64(), r2.Float64())
+	assert.Equal(r1.Float64OO(), r2.Float64OO())
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := jsf64.New(0)
+		r1.SetState([]byte("Hello"))
+	})
+	assert.Panics(func() {
+		r1 := jsf64.New(0)
+		r1.SetState(nil)
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("jsf64"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := jsf64.New(0)
+		r1.SetState(buf.Bytes())
+	})
+}
+
+func Test_JSF64_Uint64(t *testing.T) {
+	e := jsf64.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "jsf64-*-uint64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_JSF64_Float64(t *testing.T) {
+	e := jsf64.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "jsf64-*-float64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_JSF64_Float64OO(t *testing.T) {
+	e := jsf64.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "jsf64-*-float64oo-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+// Benchmarks
+func Benchmark_JSF64_Uint64(b *testing.B) {
+	rng := jsf64.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_JSF64_Float64(b *testing.B) {
+	rng := jsf64.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_JSF64_Float64OO(b *testing.B) {
+	rng := jsf64.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+// Example - JSF64 Usage
+func ExampleJSF64() {
+	// Create a new instance of the JSF64 engine
+	r := jsf64.New(20170612)
+
+	fmt.Println("JSF64: seed = 20170612; Uint64()")
+	for i := 0; i < 3; i++ {
+		// Draw 64 random bits as a uint64
+		fmt.Println(r.Uint64())
+	}
+
+	// Output:
+	// JSF64: seed = 20170612; Uint64()
+	// 11470810381308009792
+	// 1600563960846005104
+	// 4136065277286507009
+}
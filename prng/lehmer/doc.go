@@ -0,0 +1,27 @@
+// Package lehmer implements MCG128, a 128-bit multiplicative congruential
+// pseudo-random number generator, also known as a Lehmer generator.
+//
+// Each step multiplies the 128-bit state by a 64-bit constant, which takes
+// a single 64x64->128-bit multiplication (math/bits.Mul64) and a 64-bit
+// multiplication, and returns the 64 most significant bits of the state.
+// This makes MCG128 one of the fastest generators passing BigCrush. Its
+// least significant bits have short periods, but they are never returned.
+// With the multiplier used here, the engine generates the same values as
+// lehmer64 of Lemire's testingRNG collection for the same 128-bit state.
+//
+// Since the state after n draws is the initial state multiplied by the
+// n-th power of the multiplier, MCG128 implements prng.Advancer in time
+// proportional to log(n).
+//
+// References:
+//
+// D. H. Lehmer, "Mathematical methods in large-scale computing units",
+// Proceedings of the Second Symposium on Large Scale Digital Computing
+// Machinery (1951) 141--146.
+//
+// P. L'Ecuyer, "Tables of linear congruential generators of different
+// sizes and good lattice structure", Mathematics of Computation 68(225)
+// (1999) 249--260.
+//
+// https://github.com/lemire/testingRNG/blob/master/source/lehmer64.h
+package lehmer
@@ -0,0 +1,198 @@
+package lehmer
+
+import (
+	"encoding/gob"
+	"fmt"
+	"math/bits"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+var (
+	mcg128 *MCG128
+	_      prng.Engine        = mcg128
+	_      prng.Advancer      = mcg128
+	_      prng.StateRestorer = mcg128
+)
+
+func init() {
+	prng.Register("mcg128", func(seed uint64) prng.Engine { return New(seed) })
+	gob.Register(new(MCG128))
+}
+
+// multiplier is the 64-bit multiplier of the 128-bit MCG, from the tables
+// of L'Ecuyer
+const multiplier uint64 = 0xDA942042E4DD58B5
+
+// MCG128 implements a 128-bit multiplicative congruential (Lehmer)
+// generator, state = state*multiplier mod 2^128, whose output is the 64
+// most significant bits of the state. Its state is odd and its period is
+// 2^126.
+type MCG128 struct {
+	seed   uint64
+	hi, lo uint64
+}
+
+// New returns a new instance of the MCG128 PRNG Engine.
+// If the seed provided is 0, the engine is initialized with current time
+func New(seed uint64) *MCG128 {
+	r := new(MCG128)
+	r.Seed(seed)
+	return r
+}
+
+// mul returns the product of the 128-bit values (ahi, alo) and (bhi, blo)
+// modulo 2^128
+func mul(ahi, alo, bhi, blo uint64) (uint64, uint64) {
+	hi, lo := bits.Mul64(alo, blo)
+	return hi + ahi*blo + alo*bhi, lo
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64.
+// Uint64 advances the internal state of the engine.
+func (r *MCG128) Uint64() uint64 {
+	hi, lo := bits.Mul64(r.lo, multiplier)
+	r.hi = hi + r.hi*multiplier
+	r.lo = lo
+	return r.hi
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (r *MCG128) Float64() float64 {
+	return float64(r.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (r *MCG128) Float64OO() float64 {
+	return (float64(r.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine. The most and least
+// significant halves of the state are consecutive outputs of SplitMix64
+// seeded with seed, the least significant bit of the state being set.
+// If the seed provided is 0, the engine is initialized with current time
+func (r *MCG128) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	r.seed = seed
+	ms := splitmix64.New(seed)
+	r.hi = ms.Uint64()
+	r.lo = ms.Uint64() | 1
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (r *MCG128) GetSeed() uint64 { return r.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (r *MCG128) GetState() []byte {
+	return state.Encode("mcg128", r.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (r *MCG128) fields() []interface{} {
+	return []interface{}{
+		uint64(r.seed),
+		uint64(r.hi),
+		uint64(r.lo),
+	}
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (r *MCG128) SetState(b []byte) {
+	if err := r.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of MCG128, e.g. if it is truncated or if its
+// state is even
+func (r *MCG128) SetStateE(b []byte) error {
+	return r.setState(state.NewDecoder("mcg128", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (r *MCG128) setState(d *state.Decoder) error {
+	var seed, hi, lo uint64
+	d.Read(&seed, &hi, &lo)
+	if lo&1 == 0 {
+		d.Fail(fmt.Errorf("State must be odd"))
+	}
+	if err := d.Err(); err != nil {
+		return err
+	}
+	r.seed = seed
+	r.hi = hi
+	r.lo = lo
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed
+func (r *MCG128) Reset() {
+	r.Seed(r.seed)
+}
+
+// Advance advances the internal state of the engine by n steps, i.e. it is
+// equivalent to calling Uint64 n times, multiplying the state by
+// multiplier^n computed by repeated squaring in time proportional to log(n)
+func (r *MCG128) Advance(n uint64) {
+	mhi, mlo := uint64(0), multiplier
+	for ; n > 0; n >>= 1 {
+		if n&1 != 0 {
+			r.hi, r.lo = mul(r.hi, r.lo, mhi, mlo)
+		}
+		mhi, mlo = mul(mhi, mlo, mhi, mlo)
+	}
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (r *MCG128) MarshalBinary() ([]byte, error) { return r.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (r *MCG128) UnmarshalBinary(b []byte) error { return r.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (r *MCG128) MarshalText() ([]byte, error) {
+	return state.MarshalText(r.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (r *MCG128) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("mcg128", text)
+	if err != nil {
+		return err
+	}
+	return r.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (r *MCG128) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("mcg128", r.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (r *MCG128) UnmarshalJSON(data []byte) error {
+	return r.setState(state.NewJSONDecoder("mcg128", data))
+}
@@ -0,0 +1,185 @@
+package lehmer_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/lehmer"
+	"github.com/shivakar/random/prng/splitmix64"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadir = filepath.Join("..", "..", "data", "mcg128")
+var longTest bool
+
+// TestMain is the entry point for testing
+func TestMain(m *testing.M) {
+	longTest = prngtest.ParseCommandLine()
+	os.Exit(m.Run())
+}
+
+func Test_MCG128_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := lehmer.New(0)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+}
+
+func Test_MCG128_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed remains same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := lehmer.New(seed)
+		states[i] = r.GetState()
+	}
+	for i, state := range states {
+		r := lehmer.New(0)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+	}
+
+	// Checking that the streams remain same after getting and setting states
+	r1 := lehmer.New(0)
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+	}
+	r2 := lehmer.New(0)
+	r2.SetState(r1.GetState())
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+		_ = r2.Uint64()
+	}
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	assert.Equal(r1.Float64(), r2.Float64())
+	assert.Equal(r1.Float64OO(), r2.Float64OO())
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := lehmer.New(0)
+		r1.SetState([]byte("Hello"))
+	})
+	assert.Panics(func() {
+		r1 := lehmer.New(0)
+		r1.SetState(nil)
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("mcg128"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := lehmer.New(0)
+		r1.SetState(buf.Bytes())
+	})
+	// The state must be odd
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("mcg128"))
+	_ = binary.Write(buf, binary.LittleEndian, [3]uint64{10, 1, 2})
+	assert.Panics(func() {
+		r1 := lehmer.New(0)
+		r1.SetState(buf.Bytes())
+	})
+}
+
+func Test_MCG128_Uint64(t *testing.T) {
+	e := lehmer.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "mcg128-*-uint64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_MCG128_Float64(t *testing.T) {
+	e := lehmer.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "mcg128-*-float64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_MCG128_Float64OO(t *testing.T) {
+	e := lehmer.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "mcg128-*-float64oo-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_MCG128_Advance(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking that Advance matches drawing from the engine
+	r1 := lehmer.New(20170612)
+	r2 := lehmer.New(20170612)
+	for _, n := range []uint64{0, 1, 2, 3, 100, 1000} {
+		for i := uint64(0); i < n; i++ {
+			_ = r1.Uint64()
+		}
+		r2.Advance(n)
+		assert.Equal(r1.GetState(), r2.GetState())
+	}
+}
+
+// Benchmarks
+func Benchmark_MCG128_Uint64(b *testing.B) {
+	rng := lehmer.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_MCG128_Float64(b *testing.B) {
+	rng := lehmer.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_MCG128_Float64OO(b *testing.B) {
+	rng := lehmer.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+func Benchmark_MCG128_Advance(b *testing.B) {
+	rng := lehmer.New(0)
+	for i := 0; i < b.N; i++ {
+		rng.Advance(1 << 40)
+	}
+}
+
+// Benchmark_SplitMix64_Uint64 benchmarks SplitMix64 for comparison
+func Benchmark_SplitMix64_Uint64(b *testing.B) {
+	rng := splitmix64.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+// Example - MCG128 Usage
+func ExampleMCG128() {
+	// Create a new instance of the MCG128 engine
+	r := lehmer.New(20170612)
+
+	fmt.Println("MCG128: seed = 20170612; Uint64()")
+	for i := 0; i < 3; i++ {
+		// Draw 64 random bits as a uint64
+		fmt.Println(r.Uint64())
+	}
+
+	// Output:
+	// MCG128: seed = 20170612; Uint64()
+	// 11140278902761718214
+	// 15414234675239545659
+	// 1155495043847831403
+}
@@ -0,0 +1,34 @@
+// Package mrg32k3a implements the combined multiple recursive
+// pseudo-random number generator MRG32k3a of L'Ecuyer, with the streams and
+// substreams of the RngStreams package.
+//
+// The period of MRG32k3a, close to 2^191, is split into consecutive streams
+// of length 2^127, each of which is split into substreams of length 2^76.
+// MRG32k3a provides the stream and substream operations of RngStreams:
+//
+//   - NextStream, as RngStream_CreateStream: the start of the next stream
+//   - NextSubstream, as RngStream_ResetNextSubstream
+//   - ResetStartStream, as RngStream_ResetStartStream
+//   - ResetStartSubstream, as RngStream_ResetStartSubstream
+//
+// Float64OO matches RngStream_RandU01, and Float64 RngStream_RandU01 with
+// increased precision. NewWithSeeds(DefaultSeeds) is the first stream
+// created by RngStreams, and NextStream then yields the streams created
+// after it. Seed derives the seeds of the first stream from SplitMix64.
+//
+// Uint32 returns the values (x1 - x2) mod m1 of the generator, in
+// [0, 2^32-209); Uint64 concatenates two of them, and is therefore not
+// uniform over [0, 2^64).
+//
+// References:
+//
+// P. L'Ecuyer, "Good Parameters and Implementations for Combined Multiple
+// Recursive Random Number Generators", Operations Research 47(1) (1999)
+// 159--164.
+//
+// P. L'Ecuyer, R. Simard, E. J. Chen and W. D. Kelton, "An Object-Oriented
+// Random-Number Package with Many Long Streams and Substreams", Operations
+// Research 50(6) (2002) 1073--1075.
+//
+// https://www.iro.umontreal.ca/~lecuyer/myftp/streams00/
+package mrg32k3a
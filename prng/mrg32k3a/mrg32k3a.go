@@ -0,0 +1,381 @@
+package mrg32k3a
+
+import (
+	"encoding/gob"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+var (
+	mrg32k3a *MRG32k3a
+	_        prng.Engine        = mrg32k3a
+	_        prng.StateRestorer = mrg32k3a
+)
+
+func init() {
+	prng.Register("mrg32k3a", func(seed uint64) prng.Engine { return New(seed) })
+	gob.Register(new(MRG32k3a))
+}
+
+// Parameters of MRG32k3a, from RngStream.c
+const (
+	m1   uint64 = 4294967087
+	m2   uint64 = 4294944443
+	a12  uint64 = 1403580
+	a13n uint64 = 810728
+	a21  uint64 = 527612
+	a23n uint64 = 1370589
+
+	norm = 2.328306549295727688e-10 // 1/(m1+1)
+	fact = 5.9604644775390625e-8    // 2^-24
+)
+
+// Jump matrices of the two components, i.e. the transition matrices raised
+// to the powers 2^76 (substreams) and 2^127 (streams)
+var (
+	a1p76 = [3][3]uint64{
+		{82758667, 1871391091, 4127413238},
+		{3672831523, 69195019, 1871391091},
+		{3672091415, 3528743235, 69195019},
+	}
+	a2p76 = [3][3]uint64{
+		{1511326704, 3759209742, 1610795712},
+		{4292754251, 1511326704, 3889917532},
+		{3859662829, 4292754251, 3708466080},
+	}
+	a1p127 = [3][3]uint64{
+		{2427906178, 3580155704, 949770784},
+		{226153695, 1230515664, 3580155704},
+		{1988835001, 986791581, 1230515664},
+	}
+	a2p127 = [3][3]uint64{
+		{1464411153, 277697599, 1610723613},
+		{32183930, 1464411153, 1022607788},
+		{2824425944, 32183930, 2093834863},
+	}
+)
+
+// DefaultSeeds are the seeds of the first stream created by RngStreams when
+// its package seed is not set
+var DefaultSeeds = [6]uint32{12345, 12345, 12345, 12345, 12345, 12345}
+
+// MRG32k3a implements the combined multiple recursive generator MRG32k3a of
+// L'Ecuyer with period close to 2^191, split, as in the RngStreams package,
+// into streams of length 2^127, each of which is split into substreams of
+// length 2^76. The engine keeps the start of its current stream, the start
+// of its current substream and its current position.
+type MRG32k3a struct {
+	seed  uint64
+	seeds [6]uint64 // start of the first stream
+	ig    [6]uint64 // start of the current stream
+	bg    [6]uint64 // start of the current substream
+	cg    [6]uint64 // current state
+}
+
+// New returns a new instance of the MRG32k3a PRNG Engine, positioned at the
+// start of its first stream.
+// If the seed provided is 0, the engine is initialized with current time
+func New(seed uint64) *MRG32k3a {
+	r := new(MRG32k3a)
+	r.Seed(seed)
+	return r
+}
+
+// NewWithSeeds returns a new instance of the MRG32k3a PRNG Engine whose
+// first stream starts at the given seeds, as a stream created by
+// RngStream_CreateStream after RngStream_SetPackageSeed(seeds).
+// NewWithSeeds panics if the seeds are invalid; see SetSeeds.
+func NewWithSeeds(seeds [6]uint32) *MRG32k3a {
+	r := new(MRG32k3a)
+	r.SetSeeds(seeds)
+	return r
+}
+
+// step advances both components of the engine by one step and returns their
+// new values
+func (r *MRG32k3a) step() (uint64, uint64) {
+	c := &r.cg
+	// Component 1
+	p1 := (a12*c[1] + a13n*(m1-c[0])) % m1
+	c[0], c[1], c[2] = c[1], c[2], p1
+	// Component 2
+	p2 := (a21*c[5] + a23n*(m2-c[3])) % m2
+	c[3], c[4], c[5] = c[4], c[5], p2
+	return p1, p2
+}
+
+// Uint32 returns a pseudo-random value in [0, m1) as a uint32, where
+// m1 = 2^32-209 is the modulus of the first component, i.e. the combination
+// (x1 - x2) mod m1 of the two components.
+// Uint32 advances the internal state of the engine by one step.
+func (r *MRG32k3a) Uint32() uint32 {
+	p1, p2 := r.step()
+	return uint32((p1 + m1 - p2) % m1)
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64, made of two
+// consecutive values of Uint32, the first one being the most significant.
+// As Uint32 never returns values in [m1, 2^32), neither half of the result
+// takes the 209 largest 32-bit values.
+// Uint64 advances the internal state of the engine by two steps.
+func (r *MRG32k3a) Uint64() uint64 {
+	hi := uint64(r.Uint32())
+	return hi<<32 | uint64(r.Uint32())
+}
+
+// u01 returns the next value of the engine in (0.0, 1.0), with 32-bit
+// resolution
+func (r *MRG32k3a) u01() float64 {
+	p1, p2 := r.step()
+	if p1 > p2 {
+		return float64(p1-p2) * norm
+	}
+	return float64(p1+m1-p2) * norm
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64, with
+// 53-bit resolution, i.e. the output of RngStream_RandU01 after
+// RngStream_IncreasedPrecis(g, 1).
+// Float64 advances the internal state of the engine by two steps.
+func (r *MRG32k3a) Float64() float64 {
+	u := r.u01()
+	u += r.u01() * fact
+	if u < 1.0 {
+		return u
+	}
+	return u - 1.0
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64, with
+// 32-bit resolution, i.e. the output of RngStream_RandU01.
+// Float64OO advances the internal state of the engine by one step.
+func (r *MRG32k3a) Float64OO() float64 {
+	return r.u01()
+}
+
+// Seed uses the provided value to initialize the engine. The seeds of the
+// first stream are consecutive outputs of SplitMix64 seeded with seed,
+// reduced modulo m1 for the first component and m2 for the second one.
+// If the seed provided is 0, the engine is initialized with current time
+func (r *MRG32k3a) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	r.seed = seed
+	ms := splitmix64.New(seed)
+	for {
+		for i := 0; i < 3; i++ {
+			r.seeds[i] = uint64(uint32(ms.Uint64())) % m1
+		}
+		if r.seeds[0]|r.seeds[1]|r.seeds[2] != 0 {
+			break
+		}
+	}
+	for {
+		for i := 3; i < 6; i++ {
+			r.seeds[i] = uint64(uint32(ms.Uint64())) % m2
+		}
+		if r.seeds[3]|r.seeds[4]|r.seeds[5] != 0 {
+			break
+		}
+	}
+	r.Reset()
+}
+
+// SetSeeds sets the seeds of the first stream of the engine and moves the
+// engine to its start. GetSeed returns 0 after SetSeeds.
+// SetSeeds panics, as RngStream_SetSeed fails, if any of the first three
+// seeds is not less than m1 = 4294967087, if any of the last three is not
+// less than m2 = 4294944443, or if either group of three seeds is all zero.
+func (r *MRG32k3a) SetSeeds(seeds [6]uint32) {
+	var s [6]uint64
+	for i, v := range seeds {
+		s[i] = uint64(v)
+	}
+	if err := checkSeeds(s); err != nil {
+		panic(strings.Join([]string{"mrg32k3a: Invalid seeds", err.Error()}, "\n"))
+	}
+	r.seed = 0
+	r.seeds = s
+	r.Reset()
+}
+
+// checkSeeds checks that s is a valid state of the engine
+func checkSeeds(s [6]uint64) error {
+	for i := 0; i < 3; i++ {
+		if s[i] >= m1 {
+			return fmt.Errorf("Seed[%d] = %d >= m1", i, s[i])
+		}
+	}
+	for i := 3; i < 6; i++ {
+		if s[i] >= m2 {
+			return fmt.Errorf("Seed[%d] = %d >= m2", i, s[i])
+		}
+	}
+	if s[0]|s[1]|s[2] == 0 {
+		return fmt.Errorf("First 3 seeds = 0")
+	}
+	if s[3]|s[4]|s[5] == 0 {
+		return fmt.Errorf("Last 3 seeds = 0")
+	}
+	return nil
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (r *MRG32k3a) GetSeed() uint64 { return r.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (r *MRG32k3a) GetState() []byte {
+	return state.Encode("mrg32k3a", r.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (r *MRG32k3a) fields() []interface{} {
+	return []interface{}{
+		uint64(r.seed),
+		r.seeds,
+		r.ig,
+		r.bg,
+		r.cg,
+	}
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (r *MRG32k3a) SetState(b []byte) {
+	if err := r.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of MRG32k3a, e.g. if it is truncated or if
+// one of its seeds is invalid
+func (r *MRG32k3a) SetStateE(b []byte) error {
+	return r.setState(state.NewDecoder("mrg32k3a", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (r *MRG32k3a) setState(d *state.Decoder) error {
+	var seed uint64
+	var seeds, ig, bg, cg [6]uint64
+	d.Read(&seed, &seeds, &ig, &bg, &cg)
+	for _, s := range [][6]uint64{seeds, ig, bg, cg} {
+		if err := checkSeeds(s); err != nil {
+			d.Fail(err)
+		}
+	}
+	if err := d.Err(); err != nil {
+		return err
+	}
+	r.seed = seed
+	r.seeds = seeds
+	r.ig = ig
+	r.bg = bg
+	r.cg = cg
+	return nil
+}
+
+// Reset reverts the internal state of the engine to the start of its first
+// stream, keeping the seed
+func (r *MRG32k3a) Reset() {
+	r.ig = r.seeds
+	r.bg = r.seeds
+	r.cg = r.seeds
+}
+
+// NextStream moves the engine to the start of the stream following its
+// current stream, 2^127 steps after the start of the current stream. It
+// yields the same streams, in the same order, as successive calls to
+// RngStream_CreateStream. Since copies of an engine are independent, a new
+// stream can be created from g with
+//
+//	h := *g
+//	h.NextStream()
+func (r *MRG32k3a) NextStream() {
+	jump(&r.ig, &a1p127, &a2p127)
+	r.bg = r.ig
+	r.cg = r.ig
+}
+
+// NextSubstream moves the engine to the start of the substream following
+// its current substream, 2^76 steps after the start of the current
+// substream, as RngStream_ResetNextSubstream
+func (r *MRG32k3a) NextSubstream() {
+	jump(&r.bg, &a1p76, &a2p76)
+	r.cg = r.bg
+}
+
+// ResetStartStream moves the engine back to the start of its current
+// stream, as RngStream_ResetStartStream
+func (r *MRG32k3a) ResetStartStream() {
+	r.bg = r.ig
+	r.cg = r.ig
+}
+
+// ResetStartSubstream moves the engine back to the start of its current
+// substream, as RngStream_ResetStartSubstream
+func (r *MRG32k3a) ResetStartSubstream() {
+	r.cg = r.bg
+}
+
+// jump multiplies the two components of s by the matrices a1 and a2
+func jump(s *[6]uint64, a1, a2 *[3][3]uint64) {
+	var t [6]uint64
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			t[i] = (t[i] + a1[i][j]*s[j]%m1) % m1
+			t[i+3] = (t[i+3] + a2[i][j]*s[j+3]%m2) % m2
+		}
+	}
+	*s = t
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (r *MRG32k3a) MarshalBinary() ([]byte, error) { return r.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (r *MRG32k3a) UnmarshalBinary(b []byte) error { return r.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (r *MRG32k3a) MarshalText() ([]byte, error) {
+	return state.MarshalText(r.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (r *MRG32k3a) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("mrg32k3a", text)
+	if err != nil {
+		return err
+	}
+	return r.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (r *MRG32k3a) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("mrg32k3a", r.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (r *MRG32k3a) UnmarshalJSON(data []byte) error {
+	return r.setState(state.NewJSONDecoder("mrg32k3a", data))
+}
@@ -0,0 +1,289 @@
+package mrg32k3a_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/mrg32k3a"
+	"github.com/shivakar/random/prng/splitmix64"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadir = filepath.Join("..", "..", "data", "mrg32k3a")
+var longTest bool
+
+// TestMain is the entry point for testing
+func TestMain(m *testing.M) {
+	longTest = prngtest.ParseCommandLine()
+	os.Exit(m.Run())
+}
+
+func Test_MRG32k3a_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := mrg32k3a.New(0)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+	r.SetSeeds(mrg32k3a.DefaultSeeds)
+	assert.Zero(r.GetSeed())
+
+	// Checking invalid seeds
+	invalid := [][6]uint32{
+		{4294967087, 1, 1, 1, 1, 1},
+		{1, 1, 1, 1, 1, 4294944443},
+		{0, 0, 0, 1, 1, 1},
+		{1, 1, 1, 0, 0, 0},
+	}
+	for _, s := range invalid {
+		assert.Panics(func() {
+			_ = mrg32k3a.NewWithSeeds(s)
+		})
+	}
+	assert.NotPanics(func() {
+		_ = mrg32k3a.NewWithSeeds([6]uint32{4294967086, 0, 0, 0, 0, 4294944442})
+	})
+}
+
+func Test_MRG32k3a_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed remains same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := mrg32k3a.New(seed)
+		states[i] = r.GetState()
+	}
+
+	for i, state := range states {
+		r := mrg32k3a.New(0)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+	}
+
+	// Checking that the streams remain same after
+	// getting and setting states
+	r1 := mrg32k3a.New(0)
+	r1.NextStream()
+	r1.NextSubstream()
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+	}
+	r2 := mrg32k3a.New(0)
+	r2.SetState(r1.GetState())
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+		_ = r2.Uint64()
+	}
+	assert.Equal(r1.Uint32(), r2.Uint32())
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	assert.Equal(r1.Float64(), r2.Float64())
+	assert.Equal(r1.Float64OO(), r2.Float64OO())
+
+	// Checking that the starts of the stream and substream are restored
+	r1.ResetStartSubstream()
+	r2.ResetStartSubstream()
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	r1.ResetStartStream()
+	r2.ResetStartStream()
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	r1.Reset()
+	r2.Reset()
+	assert.Equal(r1.Uint64(), r2.Uint64())
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := mrg32k3a.New(0)
+		r1.SetState([]byte("Hello"))
+	})
+	assert.Panics(func() {
+		r1 := mrg32k3a.New(0)
+		r1.SetState(nil)
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("mrg32k3b"))
+	assert.Panics(func() {
+		r1 := mrg32k3a.New(0)
+		r1.SetState(buf.Bytes())
+	})
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("mrg32k3a"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, [20]uint64{})
+	assert.Panics(func() {
+		r1 := mrg32k3a.New(0)
+		r1.SetState(buf.Bytes())
+	})
+	// Invalid state values
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("mrg32k3a"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, [24]uint64{})
+	assert.Panics(func() {
+		r1 := mrg32k3a.New(0)
+		r1.SetState(buf.Bytes())
+	})
+}
+
+func Test_MRG32k3a_Uint32(t *testing.T) {
+	e := mrg32k3a.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "mrg32k3a-*-uint32-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_MRG32k3a_Uint64(t *testing.T) {
+	e := mrg32k3a.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "mrg32k3a-*-uint64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_MRG32k3a_Float64(t *testing.T) {
+	e := mrg32k3a.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "mrg32k3a-*-float64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_MRG32k3a_Float64OO(t *testing.T) {
+	e := mrg32k3a.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "mrg32k3a-*-float64oo-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_MRG32k3a_Seed(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking that the seeds of the first stream are derived from
+	// SplitMix64
+	sm := splitmix64.New(20170612)
+	var seeds [6]uint32
+	for i := range seeds {
+		seeds[i] = uint32(sm.Uint64())
+	}
+	r1 := mrg32k3a.New(20170612)
+	r2 := mrg32k3a.NewWithSeeds(seeds)
+	for i := 0; i < 10; i++ {
+		assert.Equal(r2.Uint64(), r1.Uint64())
+	}
+}
+
+func Test_MRG32k3a_Streams(t *testing.T) {
+	assert := assert.New(t)
+
+	// First outputs of RngStream_RandU01 for the first stream created with
+	// the default package seed
+	g := mrg32k3a.NewWithSeeds(mrg32k3a.DefaultSeeds)
+	expected := []float64{0.12701112204657714, 0.3185275653967945,
+		0.30918601558327008}
+	for _, v := range expected {
+		assert.Equal(v, g.Float64OO())
+	}
+
+	// Substreams
+	g.NextSubstream()
+	assert.Equal(0.079398989797334632, g.Float64OO())
+	assert.Equal(0.48033950475757409, g.Float64OO())
+	g.ResetStartSubstream()
+	assert.Equal(0.079398989797334632, g.Float64OO())
+	g.NextSubstream()
+	assert.Equal(0.26198340614618471, g.Float64OO())
+	g.ResetStartStream()
+	assert.Equal(expected[0], g.Float64OO())
+
+	// The second stream created by RngStreams starts at the seeds below
+	h := mrg32k3a.NewWithSeeds([6]uint32{3692455944, 1366884236,
+		2968912127, 335948734, 4161675175, 475798818})
+	g.NextSubstream()
+	g.NextStream()
+	for i := 0; i < 10; i++ {
+		assert.Equal(h.Uint64(), g.Uint64())
+	}
+	g.ResetStartStream()
+	assert.Equal(0.7595818622487196, g.Float64OO())
+	g.NextStream()
+	assert.Equal(0.72850978619652706, g.Float64OO())
+
+	// Copies of an engine are independent
+	g.Reset()
+	c := *g
+	c.NextStream()
+	assert.Equal(expected[0], g.Float64OO())
+	assert.Equal(0.7595818622487196, c.Float64OO())
+}
+
+func Test_MRG32k3a_Float64Precision(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking that Float64 combines two steps, as RngStream_RandU01 with
+	// increased precision
+	g := mrg32k3a.NewWithSeeds(mrg32k3a.DefaultSeeds)
+	assert.Equal(0.12701114103229952, g.Float64())
+	assert.Equal(uint64(5703475027969225352), g.Uint64())
+	assert.Equal(uint32(951893194), g.Uint32())
+}
+
+// Benchmarks
+func Benchmark_MRG32k3a_Uint32(b *testing.B) {
+	rng := mrg32k3a.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint32()
+	}
+}
+
+func Benchmark_MRG32k3a_Uint64(b *testing.B) {
+	rng := mrg32k3a.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_MRG32k3a_Float64(b *testing.B) {
+	rng := mrg32k3a.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_MRG32k3a_Float64OO(b *testing.B) {
+	rng := mrg32k3a.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+func Benchmark_MRG32k3a_NextSubstream(b *testing.B) {
+	rng := mrg32k3a.New(0)
+	for i := 0; i < b.N; i++ {
+		rng.NextSubstream()
+	}
+}
+
+// Example - Streams and substreams of MRG32k3a, as in RngStreams
+func ExampleMRG32k3a() {
+	// The first stream created by RngStreams
+	g := mrg32k3a.NewWithSeeds(mrg32k3a.DefaultSeeds)
+
+	// Create a second stream, independent of the first one
+	h := *g
+	h.NextStream()
+
+	fmt.Printf("%.10f %.10f\n", g.Float64OO(), h.Float64OO())
+
+	// Use one substream per replication of a simulation
+	g.NextSubstream()
+	fmt.Printf("%.10f\n", g.Float64OO())
+
+	// Output:
+	// 0.1270111220 0.7595818622
+	// 0.0793989898
+}
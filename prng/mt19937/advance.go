@@ -0,0 +1,253 @@
+package mt19937
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// Jump-ahead for MT19937 follows H. Haramoto, M. Matsumoto, T. Nishimura,
+// F. Panneton, P. L'Ecuyer, "Efficient Jump Ahead for F2-Linear Random
+// Number Generators", INFORMS Journal on Computing 20(3), 2008.
+//
+// The transition function T of the engine is linear over GF(2). If phi(x)
+// is its characteristic polynomial and g(x) = x^n mod phi(x), then
+// T^n = g(T), which only requires deg(phi) steps of the engine once g has
+// been computed with O(log n) polynomial squarings.
+
+const (
+	// degree of the characteristic polynomial
+	degree = 19937
+
+	// polyWords is the number of words needed to store a polynomial of
+	// degree less than or equal to degree
+	polyWords = (degree + 64) / 64
+
+	// advanceThreshold is the number of steps below which Advance simply
+	// draws from the engine instead of computing the jump polynomial
+	advanceThreshold = 1 << 23
+)
+
+var (
+	charPolyOnce sync.Once
+	charPoly     []uint64
+
+	// charPolyShifts[k] is charPoly shifted left by k bits, for k < 64
+	charPolyShifts [64][]uint64
+)
+
+// characteristicPolynomial returns the characteristic polynomial of the
+// MT19937 transition function, one coefficient per bit. It is computed once,
+// on first use, from the output of the engine using the Berlekamp-Massey
+// algorithm.
+func characteristicPolynomial() []uint64 {
+	charPolyOnce.Do(func() {
+		// Output bit 0 of the engine is a linear function of its state,
+		// hence 2*degree bits determine its minimal polynomial, which is
+		// phi since phi is primitive
+		const n = 2 * degree
+		seq := make([]uint64, n/64+2)
+		r := New(5489)
+		for i := 0; i < n; i++ {
+			seq[i/64] |= (r.Uint64() & 1) << uint(i%64)
+		}
+		c, l := berlekampMassey(seq, n)
+		if l != degree {
+			panic("mt19937: unexpected degree of characteristic polynomial")
+		}
+		// phi(x) = x^l * c(1/x)
+		charPoly = make([]uint64, polyWords)
+		for i := 0; i <= l; i++ {
+			if c[i/64]>>uint(i%64)&1 != 0 {
+				j := l - i
+				charPoly[j/64] |= uint64(1) << uint(j%64)
+			}
+		}
+		for k := range charPolyShifts {
+			charPolyShifts[k] = make([]uint64, polyWords+1)
+			xorShifted(charPolyShifts[k], charPoly, k)
+		}
+	})
+	return charPoly
+}
+
+// berlekampMassey returns the connection polynomial and the linear
+// complexity of the first n bits of the sequence seq
+func berlekampMassey(seq []uint64, n int) ([]uint64, int) {
+	words := n/64 + 2
+	// rev holds the sequence in reverse order, so that the discrepancy is
+	// the parity of c AND a window of rev. The window may extend past the
+	// end of the sequence, where the coefficients of c are zero.
+	rev := make([]uint64, 2*words)
+	for i := 0; i < n; i++ {
+		if seq[i/64]>>uint(i%64)&1 != 0 {
+			j := n - 1 - i
+			rev[j/64] |= uint64(1) << uint(j%64)
+		}
+	}
+	c := make([]uint64, words)
+	b := make([]uint64, words)
+	t := make([]uint64, words)
+	c[0], b[0] = 1, 1
+	l, m := 0, 1
+	for i := 0; i < n; i++ {
+		off := n - 1 - i
+		d := 0
+		for w := 0; w <= l/64; w++ {
+			pos := off + 64*w
+			q, r := pos/64, uint(pos%64)
+			win := rev[q] >> r
+			if r != 0 {
+				win |= rev[q+1] << (64 - r)
+			}
+			d += bits.OnesCount64(c[w] & win)
+		}
+		if d&1 == 0 {
+			m++
+			continue
+		}
+		if 2*l <= i {
+			copy(t, c)
+			xorShifted(c, b, m)
+			l = i + 1 - l
+			b, t = t, b
+			m = 1
+		} else {
+			xorShifted(c, b, m)
+			m++
+		}
+	}
+	return c, l
+}
+
+// xorShifted sets dst to dst XOR (src << shift), discarding overflowing bits
+func xorShifted(dst, src []uint64, shift int) {
+	ws, bs := shift/64, uint(shift%64)
+	for k := 0; k < len(src) && k+ws < len(dst); k++ {
+		dst[k+ws] ^= src[k] << bs
+		if bs != 0 && k+ws+1 < len(dst) {
+			dst[k+ws+1] ^= src[k] >> (64 - bs)
+		}
+	}
+}
+
+// spread interleaves the bits of the lower 32 bits of x with zeros, i.e. it
+// squares the corresponding polynomial over GF(2)
+func spread(x uint64) uint64 {
+	x &= 0x00000000FFFFFFFF
+	x = (x | (x << 16)) & 0x0000FFFF0000FFFF
+	x = (x | (x << 8)) & 0x00FF00FF00FF00FF
+	x = (x | (x << 4)) & 0x0F0F0F0F0F0F0F0F
+	x = (x | (x << 2)) & 0x3333333333333333
+	x = (x | (x << 1)) & 0x5555555555555555
+	return x
+}
+
+// xPowMod returns x^n modulo the characteristic polynomial
+func xPowMod(n uint64) []uint64 {
+	phi := characteristicPolynomial()
+	g := make([]uint64, polyWords)
+	sq := make([]uint64, 2*polyWords+1)
+	g[0] = 1
+	for i := bits.Len64(n) - 1; i >= 0; i-- {
+		// g = g^2 mod phi
+		for k := range sq {
+			sq[k] = 0
+		}
+		for k, w := range g {
+			sq[2*k] = spread(w)
+			sq[2*k+1] = spread(w >> 32)
+		}
+		for j := 2 * (degree - 1); j >= degree; j-- {
+			if sq[j/64]>>uint(j%64)&1 != 0 {
+				shift := j - degree
+				ps := charPolyShifts[shift%64]
+				d := sq[shift/64 : shift/64+len(ps)]
+				for k := range d {
+					d[k] ^= ps[k]
+				}
+			}
+		}
+		copy(g, sq[:polyWords])
+
+		// g = g*x mod phi
+		if n>>uint(i)&1 != 0 {
+			for k := polyWords - 1; k > 0; k-- {
+				g[k] = g[k]<<1 | g[k-1]>>63
+			}
+			g[0] <<= 1
+			if g[degree/64]>>uint(degree%64)&1 != 0 {
+				for k := range g {
+					g[k] ^= phi[k]
+				}
+			}
+		}
+	}
+	return g
+}
+
+// linearState is the state of the engine in incremental form: a circular
+// buffer of nn words, where p is the position of the next word to be
+// generated
+type linearState struct {
+	s [nn]uint64
+	p int
+}
+
+// next generates the word at position p and moves p forward
+func (l *linearState) next() {
+	i := l.p
+	j := i + 1
+	if j == nn {
+		j = 0
+	}
+	k := i + mm
+	if k >= nn {
+		k -= nn
+	}
+	y := (l.s[i] & um) | (l.s[j] & lm)
+	l.s[i] = l.s[k] ^ (y >> 1) ^ ((y & 1) * matrixA)
+	l.p = j
+}
+
+// add sets l to l XOR o, aligning the two buffers on their positions
+func (l *linearState) add(o *linearState) {
+	for j := 0; j < nn; j++ {
+		l.s[(l.p+j)%nn] ^= o.s[(o.p+j)%nn]
+	}
+}
+
+// Advance advances the internal state of the engine by n steps, i.e. it is
+// equivalent to, but for large n much faster than, calling Uint64 n times.
+// The number of operations needed is proportional to log(n).
+func (r *MT19937) Advance(n uint64) {
+	// Skip over the words already generated in the current block
+	for ; n > 0 && r.index < nn; n-- {
+		r.index++
+	}
+	if n < advanceThreshold {
+		for ; n > 0; n-- {
+			_ = r.Uint64()
+		}
+		return
+	}
+
+	// With r.index == nn the next call to Uint64 regenerates the word at
+	// position 0, i.e. the state is equivalent to the incremental form with
+	// p = 0. T^n = T*g(T) with g(x) = x^(n-1) mod phi(x); the extra step
+	// ensures that components of the state in the kernel of T, which do not
+	// affect the output, are cleared.
+	g := xPowMod(n - 1)
+	cur := linearState{s: r.state}
+	acc := linearState{p: nn - 1}
+	for i := 0; i < degree; i++ {
+		if g[i/64]>>uint(i%64)&1 != 0 {
+			acc.add(&cur)
+		}
+		cur.next()
+	}
+	acc.next()
+
+	// acc.p is now 0, which again corresponds to r.index == nn
+	r.state = acc.s
+	r.index = nn
+}
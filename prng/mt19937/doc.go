@@ -1,12 +1,24 @@
 // Package mt19937 implements 64-bit variant of Mersenne Twister pseudo-random
 // number generator.
 //
+// Besides Seed, which uses init_genrand64, SeedArray initializes the engine
+// from a key of any length using init_by_array64 of the reference
+// implementation, and SeedSeq as C++ std::mt19937_64 seeded from a
+// std::seed_seq.
+//
+// MT19937 implements prng.Advancer: Advance skips ahead in the output stream
+// in time proportional to log(n) using the characteristic polynomial of the
+// engine, as described in Haramoto et al. (2008).
+//
 // References:
 //
 // M. Matsumoto and T. Nishimura, "Mersenne Twister: a 623-dimensionally
 // equidistributed uniform pseudorandom number generator", ACM
 // Transactions on Modeling and Computer Simulation 8. (Jan. 1998) 3--30.
 //
-// http://www.math.sci.hiroshima-u.ac.jp/%7Em-mat/MT/emt64.html
+// H. Haramoto, M. Matsumoto, T. Nishimura, F. Panneton and P. L'Ecuyer,
+// "Efficient Jump Ahead for F2-Linear Random Number Generators", INFORMS
+// Journal on Computing 20(3) (2008) 385--390.
 //
+// http://www.math.sci.hiroshima-u.ac.jp/%7Em-mat/MT/emt64.html
 package mt19937
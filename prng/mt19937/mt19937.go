@@ -1,20 +1,25 @@
 package mt19937
 
 import (
-	"bytes"
-	"encoding/binary"
+	"encoding/gob"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
 )
 
 var (
 	mt19937 *MT19937
-	_       prng.Engine = mt19937
+	_       prng.Engine        = mt19937
+	_       prng.StateRestorer = mt19937
 )
 
+func init() {
+	prng.Register("mt19937", func(seed uint64) prng.Engine { return New(seed) })
+	gob.Register(new(MT19937))
+}
+
 // Constants
 const (
 	nn      int    = 312
@@ -22,12 +27,15 @@ const (
 	matrixA uint64 = 0xB5026F5AA96619E9
 	um      uint64 = 0xFFFFFFFF80000000 // Most significant 33 bits
 	lm      uint64 = 0x000000007FFFFFFF // Least significant 31 bits
+	seqFlag uint64 = 1 << 63            // Key length flag of SeedSeq in states
 )
 
 // MT19937 implements the 64-bit variant of the Mersenne Twister algorithm
 // based on the Mersenne prime 2^19937-1 as its period.
 type MT19937 struct {
 	seed  uint64
+	key   []uint64
+	seq   bool // key is the sequence of SeedSeq
 	index int
 	state [nn]uint64
 }
@@ -40,6 +48,22 @@ func New(seed uint64) *MT19937 {
 	return r
 }
 
+// NewWithArray returns a new instance of the MT19937 PRNG Engine
+// initialized with the given key using init_by_array64
+func NewWithArray(key []uint64) *MT19937 {
+	r := new(MT19937)
+	r.SeedArray(key)
+	return r
+}
+
+// NewWithSeedSeq returns a new instance of the MT19937 PRNG Engine
+// initialized as std::mt19937_64 seeded from a std::seed_seq of seq
+func NewWithSeedSeq(seq []uint32) *MT19937 {
+	r := new(MT19937)
+	r.SeedSeq(seq)
+	return r
+}
+
 /*
  * Implement 'Engine' interface
  */
@@ -94,6 +118,41 @@ func (r *MT19937) Seed(seed uint64) {
 		seed = uint64(time.Now().UnixNano())
 	}
 	r.seed = seed
+	r.key = nil
+	r.seq = false
+	r.initGenrand(seed)
+}
+
+// SeedArray initializes the engine with the given key as in init_by_array64
+// of the reference implementation, e.g. to use seeds larger than 64 bits.
+// Note that C++ std::seed_seq mixes its input differently: see SeedSeq.
+// GetSeed returns 0 after SeedArray. SeedArray panics if the key is empty.
+func (r *MT19937) SeedArray(key []uint64) {
+	if len(key) == 0 {
+		panic("mt19937: Empty key")
+	}
+	r.seed = 0
+	r.key = append([]uint64(nil), key...)
+	r.seq = false
+	r.initByArray(r.key)
+}
+
+// SeedSeq initializes the engine as std::mt19937_64 seeded from a
+// std::seed_seq constructed from seq, e.g. std::seed_seq{1, 2, 3, 4}, so
+// that the engine generates the same stream. GetSeed returns 0 after
+// SeedSeq.
+func (r *MT19937) SeedSeq(seq []uint32) {
+	r.seed = 0
+	r.key = make([]uint64, len(seq))
+	for i, v := range seq {
+		r.key[i] = uint64(v)
+	}
+	r.seq = true
+	r.initSeedSeq(r.key)
+}
+
+// initGenrand initializes the state using a 64-bit seed
+func (r *MT19937) initGenrand(seed uint64) {
 	r.state[0] = seed
 	for mti := uint64(1); mti < uint64(nn); mti++ {
 		r.state[mti] = (uint64(6364136223846793005)*
@@ -102,66 +161,184 @@ func (r *MT19937) Seed(seed uint64) {
 	r.index = nn
 }
 
+// initByArray initializes the state using an array of 64-bit words
+func (r *MT19937) initByArray(key []uint64) {
+	r.initGenrand(19650218)
+	i, j := 1, 0
+	k := nn
+	if len(key) > k {
+		k = len(key)
+	}
+	for ; k > 0; k-- {
+		r.state[i] = (r.state[i] ^ ((r.state[i-1] ^ (r.state[i-1] >> 62)) *
+			3935559000370003845)) + key[j] + uint64(j)
+		i++
+		j++
+		if i >= nn {
+			r.state[0] = r.state[nn-1]
+			i = 1
+		}
+		if j >= len(key) {
+			j = 0
+		}
+	}
+	for k = nn - 1; k > 0; k-- {
+		r.state[i] = (r.state[i] ^ ((r.state[i-1] ^ (r.state[i-1] >> 62)) *
+			2862933555777941757)) - uint64(i)
+		i++
+		if i >= nn {
+			r.state[0] = r.state[nn-1]
+			i = 1
+		}
+	}
+	r.state[0] = 1 << 63 // MSB is 1, assuring non-zero initial array
+	r.index = nn
+}
+
 // GetSeed returns the seed used to initialize the engine
 func (r *MT19937) GetSeed() uint64 { return r.seed }
 
 // GetState returns the internal state of the engine as []byte
 // GetState can be used to save the state, e.g. to a file
 func (r *MT19937) GetState() []byte {
-	const msg = "mt19937: Error encoding state"
-	buf := new(bytes.Buffer)
-	data := []interface{}{
-		[]byte("mt19937"),
-		uint64(r.seed),
-		uint64(r.index),
-	}
-
-	for _, v := range data {
-		if err := binary.Write(buf, binary.LittleEndian, v); err != nil {
-			panic(strings.Join([]string{msg, err.Error()}, "\n"))
-		}
-	}
+	return state.Encode("mt19937", r.fields()...)
+}
 
-	for _, v := range r.state {
-		if err := binary.Write(buf, binary.LittleEndian, v); err != nil {
-			panic(strings.Join([]string{msg, err.Error()}, "\n"))
+// fields returns the fields of the internal state of the engine
+func (r *MT19937) fields() []interface{} {
+	fields := []interface{}{uint64(r.seed), uint64(r.index), r.state}
+	// The key of SeedArray or the sequence of SeedSeq, if any, follows the
+	// state so that states of engines initialized by Seed keep their
+	// original layout. The most significant bit of its length is set for
+	// SeedSeq.
+	if r.key != nil {
+		keyLen := uint64(len(r.key))
+		if r.seq {
+			keyLen |= seqFlag
 		}
+		fields = append(fields, keyLen, r.key)
 	}
-	return buf.Bytes()
+	return fields
 }
 
 // SetState sets the internal state of the engine from a []byte
 // SetState can be used to resume from a saved state
 func (r *MT19937) SetState(b []byte) {
-	const msg = "mt19937: Error decoding state"
-	buf := bytes.NewReader(b)
-	nb := make([]byte, 7)
-	_, err := buf.Read(nb)
-	if err != nil {
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+	if err := r.SetStateE(b); err != nil {
+		panic(err.Error())
 	}
-	if string(nb) != "mt19937" {
-		err = fmt.Errorf("Expected 'mt19937', got '%s'", string(nb))
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of MT19937, e.g. if it is truncated or if
+// its index is not in [0, 312]
+func (r *MT19937) SetStateE(b []byte) error {
+	return r.setState(state.NewDecoder("mt19937", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (r *MT19937) setState(d *state.Decoder) error {
+	var seed, index uint64
+	var st [nn]uint64
+	d.Read(&seed, &index, &st)
+	if index > uint64(nn) {
+		d.Fail(fmt.Errorf("Invalid index %d", index))
 	}
-	if err = binary.Read(buf, binary.LittleEndian, &r.seed); err != nil {
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+	if isZero(st[:]) {
+		d.Fail(fmt.Errorf("State must not be all zero"))
 	}
-	var index uint64
-	if err = binary.Read(buf, binary.LittleEndian, &index); err != nil {
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+
+	// Optional key of SeedArray or sequence of SeedSeq
+	var key []uint64
+	var seq bool
+	if d.Len() > 0 {
+		var keyLen uint64
+		d.Read(&keyLen)
+		seq = keyLen&seqFlag != 0
+		keyLen &^= seqFlag
+		if (keyLen == 0 && !seq) || keyLen > uint64(d.Len())/8 {
+			d.Fail(fmt.Errorf("Invalid key length %d", keyLen))
+		} else {
+			key = make([]uint64, keyLen)
+			d.Read(key)
+		}
+		for _, v := range key {
+			if seq && v > 0xFFFFFFFF {
+				d.Fail(fmt.Errorf("Invalid seed sequence value %d", v))
+			}
+		}
 	}
+	if err := d.Err(); err != nil {
+		return err
+	}
+	r.seed = seed
 	r.index = int(index)
+	r.state = st
+	r.key = key
+	r.seq = seq
+	return nil
+}
 
-	for i := 0; i < nn; i++ {
-		if err = binary.Read(buf, binary.LittleEndian, &r.state[i]); err != nil {
-			panic(strings.Join([]string{msg, err.Error()}, "\n"))
+// isZero reports whether all the words of s are zero
+func isZero(s []uint64) bool {
+	for _, v := range s {
+		if v != 0 {
+			return false
 		}
 	}
+	return true
 }
 
 // Reset reverts the internal state of the engine to its default state,
-// except the seed
+// except the seed, or the key if the engine was initialized by SeedArray or
+// SeedSeq
 func (r *MT19937) Reset() {
+	if r.key != nil && r.seq {
+		r.initSeedSeq(r.key)
+		return
+	}
+	if r.key != nil {
+		r.initByArray(r.key)
+		return
+	}
 	r.Seed(r.seed)
 }
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (r *MT19937) MarshalBinary() ([]byte, error) { return r.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (r *MT19937) UnmarshalBinary(b []byte) error { return r.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (r *MT19937) MarshalText() ([]byte, error) {
+	return state.MarshalText(r.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (r *MT19937) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("mt19937", text)
+	if err != nil {
+		return err
+	}
+	return r.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (r *MT19937) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("mt19937", r.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (r *MT19937) UnmarshalJSON(data []byte) error {
+	return r.setState(state.NewJSONDecoder("mt19937", data))
+}
@@ -106,22 +106,197 @@ func Test_MT19937_GetSetState(t *testing.T) {
 
 func Test_MT19937_Uint64(t *testing.T) {
 	e := mt19937.New(0)
-	filenames := prngtest.GetDataFiles(datadir, "*uint64*.txt")
+	filenames := prngtest.GetDataFiles(datadir, "mt19937-*-uint64-*.txt")
 	prngtest.CompareDraws(t, e, filenames, longTest)
 }
 
 func Test_MT19937_Float64(t *testing.T) {
 	e := mt19937.New(0)
-	filenames := prngtest.GetDataFiles(datadir, "*float64*.txt")
+	filenames := prngtest.GetDataFiles(datadir, "mt19937-*-float64-*.txt")
 	prngtest.CompareDraws(t, e, filenames, longTest)
 }
 
 func Test_MT19937_Float64OO(t *testing.T) {
 	e := mt19937.New(0)
-	filenames := prngtest.GetDataFiles(datadir, "*float64oo*.txt")
+	filenames := prngtest.GetDataFiles(datadir, "mt19937-*-float64oo-*.txt")
 	prngtest.CompareDraws(t, e, filenames, longTest)
 }
 
+// arrayEngine initializes MT19937 by SeedArray with the key
+// {seed, 0x23456, 0x34567, 0x45678}, which for seed 0x12345 is the key used
+// in mt19937-64.out of the reference implementation
+type arrayEngine struct {
+	*mt19937.MT19937
+}
+
+func (e arrayEngine) Seed(seed uint64) {
+	e.SeedArray([]uint64{seed, 0x23456, 0x34567, 0x45678})
+}
+
+func Test_MT19937_SeedArray(t *testing.T) {
+	assert := assert.New(t)
+
+	// First outputs of genrand64_int64 in mt19937-64.out of the reference
+	// implementation
+	expected := []uint64{7266447313870364031, 4946485549665804864,
+		16945909448695747420, 16394063075524226720, 4873882236456199058}
+	r := mt19937.NewWithArray([]uint64{0x12345, 0x23456, 0x34567, 0x45678})
+	assert.Zero(r.GetSeed())
+	for _, v := range expected {
+		assert.Equal(v, r.Uint64())
+	}
+
+	// Checking that Reset and SetState keep the key, and that Seed
+	// discards it
+	r.Reset()
+	assert.Equal(expected[0], r.Uint64())
+	r2 := mt19937.New(1)
+	r2.SetState(r.GetState())
+	assert.Equal(r.Uint64(), r2.Uint64())
+	r2.Reset()
+	assert.Equal(expected[0], r2.Uint64())
+	r2.Seed(5)
+	r2.Reset()
+	assert.Equal(mt19937.New(5).Uint64(), r2.Uint64())
+
+	// A key longer than the state
+	key := make([]uint64, 1000)
+	for i := range key {
+		key[i] = uint64(i)
+	}
+	r.SeedArray(key)
+	v := r.Uint64()
+	key[0] = 1
+	r.SeedArray(key)
+	assert.NotEqual(v, r.Uint64())
+
+	assert.Panics(func() {
+		r.SeedArray(nil)
+	})
+
+	// Checking cases where SetState should panic on the key
+	for _, keyLen := range []uint64{0, 5} {
+		state := mt19937.New(1).GetState()
+		buf := bytes.NewBuffer(state)
+		_ = binary.Write(buf, binary.LittleEndian, keyLen)
+		_ = binary.Write(buf, binary.LittleEndian, []uint64{1, 2})
+		assert.Panics(func() {
+			r1 := mt19937.New(0)
+			r1.SetState(buf.Bytes())
+		})
+	}
+}
+
+func Test_MT19937_SeedArray_Draws(t *testing.T) {
+	e := arrayEngine{mt19937.New(1)}
+	for _, fn := range []string{"uint64", "float64", "float64oo"} {
+		filenames := prngtest.GetDataFiles(datadir, "mt19937array-*-"+fn+"-*.txt")
+		prngtest.CompareDraws(t, e, filenames, longTest)
+	}
+}
+
+func Test_MT19937_SeedSeq(t *testing.T) {
+	assert := assert.New(t)
+
+	// First and 1000th outputs of std::mt19937_64 seeded from a
+	// std::seed_seq of each sequence, generated with libstdc++
+	big := make([]uint32, 1000)
+	for i := range big {
+		big[i] = uint32(i) * 2654435761
+	}
+	tests := []struct {
+		seq      []uint32
+		expected []uint64
+		v1000    uint64
+	}{
+		{[]uint32{1, 2, 3, 4}, []uint64{9587114359441678441,
+			4525004064817100860, 3550851110859804093, 17015088680911320335,
+			7653423455139280198}, 3002032454479824624},
+		{[]uint32{}, []uint64{835052665647855778, 3190053552572815828,
+			4634633302865102305, 6117669629961065221, 14178379554402451544},
+			18348909641057889230},
+		{[]uint32{20170612}, []uint64{7755665588052450198,
+			5340441152602828678, 10255074225403380083, 5353540087187472772,
+			3100531010453332145}, 15043395395782300987},
+		{big, []uint64{17542408168549410656, 4516666157408783048,
+			15345424494197834358, 12094025964958738180, 12700393793036761146},
+			3810341270396912645},
+	}
+	for _, tt := range tests {
+		r := mt19937.NewWithSeedSeq(tt.seq)
+		assert.Zero(r.GetSeed())
+		for _, v := range tt.expected {
+			assert.Equal(v, r.Uint64())
+		}
+		r.Advance(994)
+		assert.Equal(tt.v1000, r.Uint64())
+	}
+
+	// Checking that Reset and SetState keep the sequence, and that
+	// SeedArray and Seed discard it
+	r := mt19937.NewWithSeedSeq([]uint32{1, 2, 3, 4})
+	v := r.Uint64()
+	r.Reset()
+	assert.Equal(v, r.Uint64())
+	r2 := mt19937.New(1)
+	r2.SetState(r.GetState())
+	assert.Equal(r.Uint64(), r2.Uint64())
+	r2.Reset()
+	assert.Equal(v, r2.Uint64())
+	r2.SeedArray([]uint64{1, 2, 3, 4})
+	r2.Reset()
+	assert.Equal(mt19937.NewWithArray([]uint64{1, 2, 3, 4}).Uint64(), r2.Uint64())
+	r2.SeedSeq(nil)
+	r2.Seed(5)
+	r2.Reset()
+	assert.Equal(mt19937.New(5).Uint64(), r2.Uint64())
+
+	// The state of an empty sequence
+	r2.SeedSeq(nil)
+	r.SetState(r2.GetState())
+	r.Reset()
+	assert.Equal(tests[1].expected[0], r.Uint64())
+
+	// Checking that SetState panics on a value of the sequence larger than
+	// 32 bits
+	var st [312]uint64
+	st[0] = 1
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("mt19937"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(0))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(0))
+	_ = binary.Write(buf, binary.LittleEndian, st)
+	_ = binary.Write(buf, binary.LittleEndian, uint64(1<<63|1))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(1<<32))
+	assert.Panics(func() {
+		r1 := mt19937.New(0)
+		r1.SetState(buf.Bytes())
+	})
+}
+
+func Test_MT19937_Advance(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking that Advance matches drawing from the engine, both below and
+	// above the number of steps where the jump polynomial is used
+	steps := []uint64{0, 1, 311, 312, 1000, 1<<23 + 12345}
+	for _, n := range steps {
+		r1 := mt19937.New(20170612)
+		r2 := mt19937.New(20170612)
+		for i := 0; i < 100; i++ {
+			_ = r1.Uint64()
+			_ = r2.Uint64()
+		}
+		r1.Advance(n)
+		for i := uint64(0); i < n; i++ {
+			_ = r2.Uint64()
+		}
+		for i := 0; i < 1000; i++ {
+			assert.Equal(r2.Uint64(), r1.Uint64())
+		}
+	}
+}
+
 // Benchmarks
 func Benchmark_MT19937_Uint64(b *testing.B) {
 	rng := mt19937.New(0)
@@ -130,6 +305,13 @@ func Benchmark_MT19937_Uint64(b *testing.B) {
 	}
 }
 
+func Benchmark_MT19937_Advance(b *testing.B) {
+	rng := mt19937.New(0)
+	for i := 0; i < b.N; i++ {
+		rng.Advance(1e12)
+	}
+}
+
 func Benchmark_MT19937_Float64(b *testing.B) {
 	rng := mt19937.New(0)
 	for i := 0; i < b.N; i++ {
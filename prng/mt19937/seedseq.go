@@ -0,0 +1,68 @@
+package mt19937
+
+// seedSeqGenerate fills a with the values of the generate function of C++
+// std::seed_seq constructed from seq
+func seedSeqGenerate(a []uint32, seq []uint64) {
+	n := len(a)
+	if n == 0 {
+		return
+	}
+	for i := range a {
+		a[i] = 0x8b8b8b8b
+	}
+	var t int
+	switch {
+	case n >= 623:
+		t = 11
+	case n >= 68:
+		t = 7
+	case n >= 39:
+		t = 5
+	case n >= 7:
+		t = 3
+	default:
+		t = (n - 1) / 2
+	}
+	p := (n - t) / 2
+	q := p + t
+	s := len(seq)
+	m := n
+	if s+1 > m {
+		m = s + 1
+	}
+	mix := func(x uint32) uint32 { return x ^ (x >> 27) }
+	for k := 0; k < m; k++ {
+		r1 := 1664525 * mix(a[k%n]^a[(k+p)%n]^a[(k+n-1)%n])
+		r2 := r1 + uint32(k%n)
+		switch {
+		case k == 0:
+			r2 = r1 + uint32(s)
+		case k <= s:
+			r2 += uint32(seq[k-1])
+		}
+		a[(k+p)%n] += r1
+		a[(k+q)%n] += r2
+		a[k%n] = r2
+	}
+	for k := m; k < m+n; k++ {
+		r3 := 1566083941 * mix(a[k%n]+a[(k+p)%n]+a[(k+n-1)%n])
+		r4 := r3 - uint32(k%n)
+		a[(k+p)%n] ^= r3
+		a[(k+q)%n] ^= r4
+		a[k%n] = r4
+	}
+}
+
+// initSeedSeq initializes the state as std::mt19937_64::seed from a
+// std::seed_seq constructed from seq, whose values are 32-bit words
+func (r *MT19937) initSeedSeq(seq []uint64) {
+	var a [2 * nn]uint32
+	seedSeqGenerate(a[:], seq)
+	for i := range r.state {
+		r.state[i] = uint64(a[2*i]) | uint64(a[2*i+1])<<32
+	}
+	if r.state[0]&um == 0 && isZero(r.state[1:]) {
+		r.state[0] = 1 << 63
+	}
+	r.index = nn
+}
@@ -0,0 +1,26 @@
+// Package mt19937ar implements the 32-bit variant of Mersenne Twister
+// pseudo-random number generator, mt19937ar of the reference
+// implementation.
+//
+// MT19937AR generates the same sequence of 32-bit values, through Uint32,
+// as the generators of other languages using the 32-bit Mersenne Twister:
+//
+//   - C++ std::mt19937(seed): New(seed) or Seed(seed) for seeds less than
+//     2^32, using init_genrand
+//   - Python random.seed(n) for a non-negative integer n: SeedArray with
+//     the 32-bit words of n, least significant first, using init_by_array;
+//     Float64 then matches random.random() and Uint32 matches
+//     random.getrandbits(32)
+//
+// Uint64 combines two consecutive 32-bit values, the first one being the
+// most significant.
+//
+// References:
+//
+// M. Matsumoto and T. Nishimura, "Mersenne Twister: a 623-dimensionally
+// equidistributed uniform pseudorandom number generator", ACM
+// Transactions on Modeling and Computer Simulation 8. (Jan. 1998) 3--30.
+//
+// http://www.math.sci.hiroshima-u.ac.jp/%7Em-mat/MT/emt.html
+// http://www.math.sci.hiroshima-u.ac.jp/%7Em-mat/MT/MT2002/emt19937ar.html
+package mt19937ar
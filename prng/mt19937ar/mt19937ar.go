@@ -0,0 +1,304 @@
+package mt19937ar
+
+import (
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+)
+
+var (
+	mt19937ar *MT19937AR
+	_         prng.Engine        = mt19937ar
+	_         prng.StateRestorer = mt19937ar
+)
+
+func init() {
+	prng.Register("mt19937ar", func(seed uint64) prng.Engine { return New(seed) })
+	gob.Register(new(MT19937AR))
+}
+
+// Constants
+const (
+	nn      int    = 624
+	mm      int    = 397
+	matrixA uint32 = 0x9908B0DF
+	um      uint32 = 0x80000000 // Most significant bit
+	lm      uint32 = 0x7FFFFFFF // Least significant 31 bits
+)
+
+// MT19937AR implements the 32-bit Mersenne Twister algorithm, as in
+// mt19937ar.c of Matsumoto and Nishimura, based on the Mersenne prime
+// 2^19937-1 as its period.
+type MT19937AR struct {
+	seed  uint64
+	key   []uint32
+	index int
+	state [nn]uint32
+}
+
+// New returns a new instance of the MT19937AR PRNG Engine.
+// If the seed provided is 0, the engine is initialized with current time
+func New(seed uint64) *MT19937AR {
+	r := new(MT19937AR)
+	r.Seed(seed)
+	return r
+}
+
+// NewWithArray returns a new instance of the MT19937AR PRNG Engine
+// initialized with the given key using init_by_array
+func NewWithArray(key []uint32) *MT19937AR {
+	r := new(MT19937AR)
+	r.SeedArray(key)
+	return r
+}
+
+// Uint32 returns a pseudo-random 32-bit value in [0, 2^32) as a uint32,
+// i.e. the output of genrand_int32 of the reference implementation.
+// Uint32 advances the internal state of the engine.
+func (r *MT19937AR) Uint32() uint32 {
+	if r.index >= nn {
+		for i := 0; i < nn-mm; i++ {
+			y := (r.state[i] & um) | (r.state[i+1] & lm)
+			r.state[i] = r.state[i+mm] ^ (y >> 1) ^ ((y & 1) * matrixA)
+		}
+		for i := nn - mm; i < nn-1; i++ {
+			y := (r.state[i] & um) | (r.state[i+1] & lm)
+			r.state[i] = r.state[i+(mm-nn)] ^ (y >> 1) ^ ((y & 1) * matrixA)
+		}
+		y := (r.state[nn-1] & um) | (r.state[0] & lm)
+		r.state[nn-1] = r.state[mm-1] ^ (y >> 1) ^ ((y & 1) * matrixA)
+
+		r.index = 0
+	}
+	y := r.state[r.index]
+	r.index++
+
+	y ^= (y >> 11)
+	y ^= (y << 7) & 0x9D2C5680
+	y ^= (y << 15) & 0xEFC60000
+	y ^= (y >> 18)
+
+	return y
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64,
+// made of two consecutive outputs of Uint32, the first being the most
+// significant, as NumPy's MT19937 does.
+// Uint64 advances the internal state of the engine.
+func (r *MT19937AR) Uint64() uint64 {
+	hi := uint64(r.Uint32())
+	return hi<<32 | uint64(r.Uint32())
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64,
+// with 53-bit resolution from two consecutive outputs of Uint32, i.e. the
+// output of genrand_res53 of the reference implementation and of Python's
+// random.random().
+// Float64 advances the internal state of the engine.
+func (r *MT19937AR) Float64() float64 {
+	a := r.Uint32() >> 5
+	b := r.Uint32() >> 6
+	return (float64(a)*67108864.0 + float64(b)) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (r *MT19937AR) Float64OO() float64 {
+	return (float64(r.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine. Seeds less than
+// 2^32 are used as in init_genrand, i.e. as in C++ std::mt19937(seed).
+// Larger seeds are split into their least and most significant 32 bits and
+// used as key of init_by_array, which matches Python's random.seed(seed).
+// If the seed provided is 0, the engine is initialized with current time
+func (r *MT19937AR) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	r.seed = seed
+	r.key = nil
+	if seed>>32 == 0 {
+		r.initGenrand(uint32(seed))
+		return
+	}
+	r.initByArray([]uint32{uint32(seed), uint32(seed >> 32)})
+}
+
+// SeedArray initializes the engine with the given key as in init_by_array.
+// Python's random.seed(n) uses the 32-bit words of n, least significant
+// first, as key, e.g. SeedArray([]uint32{42}) matches random.seed(42).
+// GetSeed returns 0 after SeedArray. SeedArray panics if the key is empty.
+func (r *MT19937AR) SeedArray(key []uint32) {
+	if len(key) == 0 {
+		panic("mt19937ar: Empty key")
+	}
+	r.seed = 0
+	r.key = append([]uint32(nil), key...)
+	r.initByArray(r.key)
+}
+
+// initGenrand initializes the state using a 32-bit seed
+func (r *MT19937AR) initGenrand(s uint32) {
+	r.state[0] = s
+	for mti := uint32(1); mti < uint32(nn); mti++ {
+		r.state[mti] = (uint32(1812433253)*
+			(r.state[mti-1]^(r.state[mti-1]>>30)) + mti)
+	}
+	r.index = nn
+}
+
+// initByArray initializes the state using an array of 32-bit words
+func (r *MT19937AR) initByArray(key []uint32) {
+	r.initGenrand(19650218)
+	i, j := 1, 0
+	k := nn
+	if len(key) > k {
+		k = len(key)
+	}
+	for ; k > 0; k-- {
+		r.state[i] = (r.state[i] ^ ((r.state[i-1] ^ (r.state[i-1] >> 30)) *
+			1664525)) + key[j] + uint32(j)
+		i++
+		j++
+		if i >= nn {
+			r.state[0] = r.state[nn-1]
+			i = 1
+		}
+		if j >= len(key) {
+			j = 0
+		}
+	}
+	for k = nn - 1; k > 0; k-- {
+		r.state[i] = (r.state[i] ^ ((r.state[i-1] ^ (r.state[i-1] >> 30)) *
+			1566083941)) - uint32(i)
+		i++
+		if i >= nn {
+			r.state[0] = r.state[nn-1]
+			i = 1
+		}
+	}
+	r.state[0] = 0x80000000 // MSB is 1, assuring non-zero initial array
+	r.index = nn
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (r *MT19937AR) GetSeed() uint64 { return r.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (r *MT19937AR) GetState() []byte {
+	return state.Encode("mt19937ar", r.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (r *MT19937AR) fields() []interface{} {
+	return []interface{}{
+		uint64(r.seed),
+		uint64(len(r.key)),
+		r.key,
+		uint64(r.index),
+		r.state,
+	}
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (r *MT19937AR) SetState(b []byte) {
+	if err := r.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of MT19937AR, e.g. if it is truncated or if
+// its index is not in [0, 624]
+func (r *MT19937AR) SetStateE(b []byte) error {
+	return r.setState(state.NewDecoder("mt19937ar", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (r *MT19937AR) setState(d *state.Decoder) error {
+	var seed, keyLen, index uint64
+	var key []uint32
+	var st [nn]uint32
+	d.Read(&seed, &keyLen)
+	if keyLen > uint64(d.Len())/4 {
+		d.Fail(fmt.Errorf("Key length %d exceeds the data", keyLen))
+	} else if keyLen > 0 {
+		key = make([]uint32, keyLen)
+	} else if seed == 0 {
+		d.Fail(fmt.Errorf("Expected a seed or a key"))
+	}
+	d.Read(key, &index, &st)
+	if index > uint64(nn) {
+		d.Fail(fmt.Errorf("Invalid index %d", index))
+	}
+	if st == [nn]uint32{} {
+		d.Fail(fmt.Errorf("State must not be all zero"))
+	}
+	if err := d.Err(); err != nil {
+		return err
+	}
+	r.seed = seed
+	r.key = key
+	r.index = int(index)
+	r.state = st
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed, or the key if the engine was initialized by SeedArray
+func (r *MT19937AR) Reset() {
+	if r.key != nil {
+		r.initByArray(r.key)
+		return
+	}
+	r.Seed(r.seed)
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (r *MT19937AR) MarshalBinary() ([]byte, error) { return r.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (r *MT19937AR) UnmarshalBinary(b []byte) error { return r.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (r *MT19937AR) MarshalText() ([]byte, error) {
+	return state.MarshalText(r.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (r *MT19937AR) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("mt19937ar", text)
+	if err != nil {
+		return err
+	}
+	return r.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (r *MT19937AR) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("mt19937ar", r.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (r *MT19937AR) UnmarshalJSON(data []byte) error {
+	return r.setState(state.NewJSONDecoder("mt19937ar", data))
+}
@@ -0,0 +1,227 @@
+package mt19937ar_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/mt19937ar"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadir = filepath.Join("..", "..", "data", "mt19937ar")
+var longTest bool
+
+// TestMain is the entry point for testing
+func TestMain(m *testing.M) {
+	longTest = prngtest.ParseCommandLine()
+	os.Exit(m.Run())
+}
+
+func Test_MT19937AR_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+	seeds := []uint64{1, 5, 10, 1024, 200000, 6662751973}
+	r := mt19937ar.New(0)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+	r.SeedArray([]uint32{1, 2})
+	assert.Zero(r.GetSeed())
+
+	assert.Panics(func() {
+		r.SeedArray(nil)
+	})
+}
+
+func Test_MT19937AR_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed remains same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024, 6662751973}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := mt19937ar.New(seed)
+		states[i] = r.GetState()
+	}
+
+	for i, state := range states {
+		r := mt19937ar.New(0)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+	}
+
+	// Checking that the streams remain same after
+	// getting and setting states
+	r1 := mt19937ar.New(0)
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint32()
+	}
+	r2 := mt19937ar.New(0)
+	r2.SetState(r1.GetState())
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+		_ = r2.Uint64()
+	}
+	assert.Equal(r1.Uint32(), r2.Uint32())
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	assert.Equal(r1.Float64(), r2.Float64())
+	assert.Equal(r1.Float64OO(), r2.Float64OO())
+
+	// Checking that the key of SeedArray is restored, so that Reset
+	// reverts to the same stream
+	r1 = mt19937ar.NewWithArray([]uint32{0x123, 0x234, 0x345, 0x456})
+	v := r1.Uint32()
+	r2 = mt19937ar.New(0)
+	r2.SetState(r1.GetState())
+	r2.Reset()
+	assert.Equal(v, r2.Uint32())
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := mt19937ar.New(0)
+		r1.SetState([]byte("Hello"))
+	})
+
+	assert.Panics(func() {
+		r1 := mt19937ar.New(0)
+		r1.SetState(nil)
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("mt19937ar"))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := mt19937ar.New(0)
+		r1.SetState(buf.Bytes())
+	})
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("mt19937ar"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(1000))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := mt19937ar.New(0)
+		r1.SetState(buf.Bytes())
+	})
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("mt19937ar"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(0))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := mt19937ar.New(0)
+		r1.SetState(buf.Bytes())
+	})
+
+	// Index beyond the end of the state, and neither seed nor key
+	for _, v := range [][2]uint64{{10, 625}, {0, 0}} {
+		buf = new(bytes.Buffer)
+		_ = binary.Write(buf, binary.LittleEndian, []byte("mt19937ar"))
+		_ = binary.Write(buf, binary.LittleEndian, v[0])
+		_ = binary.Write(buf, binary.LittleEndian, uint64(0))
+		_ = binary.Write(buf, binary.LittleEndian, v[1])
+		_ = binary.Write(buf, binary.LittleEndian, [624]uint32{})
+		assert.Panics(func() {
+			r1 := mt19937ar.New(0)
+			r1.SetState(buf.Bytes())
+		})
+	}
+}
+
+func Test_MT19937AR_Uint32(t *testing.T) {
+	e := mt19937ar.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "*uint32*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_MT19937AR_Uint64(t *testing.T) {
+	e := mt19937ar.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "*uint64*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_MT19937AR_Float64(t *testing.T) {
+	e := mt19937ar.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "*float64*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_MT19937AR_Float64OO(t *testing.T) {
+	e := mt19937ar.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "*float64oo*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_MT19937AR_Reference(t *testing.T) {
+	assert := assert.New(t)
+
+	// First outputs of genrand_int32 in mt19937ar.out of the reference
+	// implementation, initialized by init_by_array with this key
+	expected := []uint32{1067595299, 955945823, 477289528, 4107218783,
+		4228976476, 3344332714, 3355579695, 227628506, 810200273,
+		2591290167}
+	r := mt19937ar.NewWithArray([]uint32{0x123, 0x234, 0x345, 0x456})
+	for _, v := range expected {
+		assert.Equal(v, r.Uint32())
+	}
+
+	// The 10000th output of std::mt19937 default-constructed with seed
+	// 5489, as required by the C++ standard
+	r = mt19937ar.New(5489)
+	for i := 0; i < 9999; i++ {
+		_ = r.Uint32()
+	}
+	assert.Equal(uint32(4123659995), r.Uint32())
+
+	// Python: random.seed(42); random.getrandbits(32)
+	r.SeedArray([]uint32{42})
+	assert.Equal(uint32(2746317213), r.Uint32())
+}
+
+// Benchmarks
+func Benchmark_MT19937AR_Uint32(b *testing.B) {
+	rng := mt19937ar.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint32()
+	}
+}
+
+func Benchmark_MT19937AR_Uint64(b *testing.B) {
+	rng := mt19937ar.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_MT19937AR_Float64(b *testing.B) {
+	rng := mt19937ar.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_MT19937AR_Float64OO(b *testing.B) {
+	rng := mt19937ar.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+// Example - Reproducing Python's random module
+func ExampleMT19937AR_SeedArray() {
+	// Python: random.seed(2017); random.random()
+	r := mt19937ar.NewWithArray([]uint32{2017})
+	fmt.Printf("%.17f\n", r.Float64())
+
+	// Output:
+	// 0.19916290376239920
+}
@@ -0,0 +1,29 @@
+// Package pcg64 implements the 128-bit permuted congruential generators
+// PCG64 (XSL-RR 128/64) and PCG64DXSM.
+//
+// Both engines use a 128-bit linear congruential generator (LCG) with a
+// selectable stream, i.e. increment, and a permutation of the LCG state as
+// output function. PCG64 is the default generator of NumPy (as
+// numpy.random.PCG64); PCG64DXSM uses a cheaper 64-bit multiplier and a
+// stronger output function and is available as numpy.random.PCG64DXSM.
+//
+// Seeding follows pcg64_srandom_r of the reference implementation with the
+// seed as the initial state and the stream as the sequence selector. The
+// LCG state and increment can also be set directly using SetLCGState, e.g.
+// to continue a stream from the state of a NumPy bit generator.
+//
+// Both engines implement prng.Advancer in time proportional to log(n).
+//
+// References:
+//
+// M. E. O'Neill, "PCG: A Family of Simple Fast Space-Efficient Statistically
+// Good Algorithms for Random Number Generation", Harvey Mudd College,
+// HMC-CS-2014-0905 (2014).
+//
+// F. B. Brown, "Random Number Generation with Arbitrary Stride", Trans. Am.
+// Nucl. Soc. (Nov. 1994).
+//
+// http://www.pcg-random.org/
+// https://github.com/imneme/pcg-c
+// https://github.com/numpy/numpy/blob/main/numpy/random/src/pcg64/pcg64.h
+package pcg64
@@ -0,0 +1,205 @@
+package pcg64
+
+import (
+	"encoding/gob"
+	"math/bits"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+)
+
+var (
+	pcg64 *PCG64
+	_     prng.Engine        = pcg64
+	_     prng.Advancer      = pcg64
+	_     prng.StateRestorer = pcg64
+)
+
+func init() {
+	prng.Register("pcg64", func(seed uint64) prng.Engine { return New(seed) })
+	gob.Register(new(PCG64))
+}
+
+// multiplier is the default 128-bit multiplier of the PCG LCG
+var multiplier = uint128{0x2360ED051FC65DA4, 0x4385DF649FCCF645}
+
+// PCG64 implements the PCG XSL-RR 128/64 generator: a 128-bit LCG whose
+// output is the xor of the two halves of the state, rotated by the six most
+// significant bits of the state. The period of each stream is 2^128.
+type PCG64 struct {
+	seed   uint64
+	stream uint64
+	lcg
+}
+
+// New returns a new instance of the PCG64 PRNG Engine using stream 0.
+// If the seed provided is 0, the engine is initialized with current time
+func New(seed uint64) *PCG64 {
+	return NewWithStream(seed, 0)
+}
+
+// NewWithStream returns a new instance of the PCG64 PRNG Engine using the
+// given stream. Engines with the same seed but different streams generate
+// different sequences.
+// If the seed provided is 0, the engine is initialized with current time
+func NewWithStream(seed uint64, stream uint64) *PCG64 {
+	r := new(PCG64)
+	r.stream = stream
+	r.Seed(seed)
+	return r
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64.
+// Uint64 advances the internal state of the engine.
+func (p *PCG64) Uint64() uint64 {
+	p.step(multiplier)
+	return bits.RotateLeft64(p.state.hi^p.state.lo, -int(p.state.hi>>58))
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (p *PCG64) Float64() float64 {
+	return float64(p.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (p *PCG64) Float64OO() float64 {
+	return (float64(p.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine on its current
+// stream. If the seed provided is 0, the engine is initialized with current
+// time
+func (p *PCG64) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	p.seed = seed
+	p.srandom(uint128{0, seed}, uint128{0, p.stream}, multiplier)
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (p *PCG64) GetSeed() uint64 { return p.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (p *PCG64) GetState() []byte {
+	return state.Encode("pcg64", p.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (p *PCG64) fields() []interface{} {
+	return []interface{}{
+		uint64(p.seed),
+		uint64(p.stream),
+		uint64(p.state.hi),
+		uint64(p.state.lo),
+		uint64(p.inc.hi),
+		uint64(p.inc.lo),
+	}
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (p *PCG64) SetState(b []byte) {
+	if err := p.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of PCG64, e.g. if it is truncated or if its
+// increment is even
+func (p *PCG64) SetStateE(b []byte) error {
+	return p.setState(state.NewDecoder("pcg64", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (p *PCG64) setState(d *state.Decoder) error {
+	seed, stream, g, err := decodeState(d)
+	if err != nil {
+		return err
+	}
+	p.seed, p.stream, p.lcg = seed, stream, g
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed and the stream
+func (p *PCG64) Reset() {
+	p.Seed(p.seed)
+}
+
+// Advance advances the internal state of the engine by n steps, i.e. it is
+// equivalent to calling Uint64 n times, in time proportional to log(n)
+func (p *PCG64) Advance(n uint64) {
+	p.advance(n, multiplier)
+}
+
+// GetStream returns the stream of the engine
+func (p *PCG64) GetStream() uint64 { return p.stream }
+
+// SetStream selects the stream of the engine and re-initializes the engine
+// with its current seed on the new stream
+func (p *PCG64) SetStream(stream uint64) {
+	p.stream = stream
+	p.Seed(p.seed)
+}
+
+// SetLCGState sets the 128-bit state and increment of the underlying LCG,
+// given as their most and least significant 64 bits. The increment is made
+// odd by setting its least significant bit.
+//
+// The engine then continues the stream of any PCG64 implementation with the
+// same LCG state, e.g. of numpy.random.PCG64 whose state is available as
+// bit_generator.state["state"]. Seed and stream are left unchanged, hence
+// Reset reverts to the seeded state.
+func (p *PCG64) SetLCGState(stateHi, stateLo, incHi, incLo uint64) {
+	p.state = uint128{stateHi, stateLo}
+	p.inc = uint128{incHi, incLo | 1}
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (p *PCG64) MarshalBinary() ([]byte, error) { return p.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (p *PCG64) UnmarshalBinary(b []byte) error { return p.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (p *PCG64) MarshalText() ([]byte, error) {
+	return state.MarshalText(p.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (p *PCG64) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("pcg64", text)
+	if err != nil {
+		return err
+	}
+	return p.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (p *PCG64) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("pcg64", p.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (p *PCG64) UnmarshalJSON(data []byte) error {
+	return p.setState(state.NewJSONDecoder("pcg64", data))
+}
@@ -0,0 +1,210 @@
+package pcg64_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/pcg64"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadir = filepath.Join("..", "..", "data", "pcg64")
+var longTest bool
+
+// TestMain is the entry point for testing
+func TestMain(m *testing.M) {
+	longTest = prngtest.ParseCommandLine()
+	os.Exit(m.Run())
+}
+
+func Test_PCG64_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := pcg64.New(0)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+}
+
+func Test_PCG64_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed and stream remain same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := pcg64.NewWithStream(seed, seed+1)
+		states[i] = r.GetState()
+	}
+	for i, state := range states {
+		r := pcg64.New(0)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+		assert.Equal(seeds[i]+1, r.GetStream())
+	}
+
+	// Checking that the streams remain same after getting and setting states
+	r1 := pcg64.NewWithStream(0, 7)
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+	}
+	r2 := pcg64.New(0)
+	r2.SetState(r1.GetState())
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+		_ = r2.Uint64()
+	}
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	assert.Equal(r1.Float64(), r2.Float64())
+	assert.Equal(r1.Float64OO(), r2.Float64OO())
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := pcg64.New(0)
+		r1.SetState([]byte("Hell"))
+	})
+	assert.Panics(func() {
+		r1 := pcg64.New(0)
+		r1.SetState(nil)
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("pcg65"))
+	assert.Panics(func() {
+		r1 := pcg64.New(0)
+		r1.SetState(buf.Bytes())
+	})
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("pcg64"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := pcg64.New(0)
+		r1.SetState(buf.Bytes())
+	})
+}
+
+func Test_PCG64_Uint64(t *testing.T) {
+	e := pcg64.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "*uint64*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_PCG64_Float64(t *testing.T) {
+	e := pcg64.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "*float64*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_PCG64_Float64OO(t *testing.T) {
+	e := pcg64.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "*float64oo*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_PCG64_Stream(t *testing.T) {
+	assert := assert.New(t)
+
+	// Expected values from pcg64_srandom_r(&rng, 42, 54) of the reference
+	// implementation
+	expected := []uint64{0x86b1da1d72062b68, 0x1304aa46c9853d39,
+		0xa3670e9e0dd50358, 0xf9090e529a7dae00, 0xc85b9fd837996f2c,
+		0x606121f8e3919196}
+	r := pcg64.NewWithStream(42, 54)
+	for _, v := range expected {
+		assert.Equal(v, r.Uint64())
+	}
+	r = pcg64.New(42)
+	assert.NotEqual(expected[0], r.Uint64())
+	r.SetStream(54)
+	assert.Equal(uint64(54), r.GetStream())
+	assert.Equal(expected[0], r.Uint64())
+
+	// Checking that the same LCG state continues the same stream
+	r = pcg64.New(1)
+	r.SetLCGState(0xde2bce05be013be3, 0xd3f6c45a41e54320, 0, 0x6d)
+	for _, v := range expected {
+		assert.Equal(v, r.Uint64())
+	}
+	r.Reset()
+	assert.Equal(pcg64.New(1).Uint64(), r.Uint64())
+}
+
+func Test_PCG64_NumPy(t *testing.T) {
+	assert := assert.New(t)
+
+	// bit_generator.state of numpy.random.PCG64(seed) for the seeds
+	// 38219308213743 and 0xdeadbeaf, and the state after advance(2**96 +
+	// 2**64 + 2**32 + 2**16 + 2**8 + 2**4 + 2**2 + 2**1) from the first one,
+	// as asserted by test_advange_large in numpy/random/tests/test_direct.py,
+	// followed by the first values of random_raw(). The values for
+	// 0xdeadbeaf start as in pcg64-testset-1.csv of NumPy.
+	tests := []struct {
+		stateHi, stateLo, incHi, incLo uint64
+		expected                       []uint64
+	}{
+		{0xd85f72c717ad8f6c, 0x6962e34f5a12f2cd, 0x7e77d7adf0727810, 0x0a8857710c4874ed,
+			[]uint64{0x3cc2a420ca027154, 0x32ca148330d9a009, 0xcf1c1086fb065693,
+				0x3f7e281054cf6bf6, 0x6da60d0ee4ce9ff0}},
+		{0xdcbf51eca8a06d6d, 0xa11c0e6f95be4591, 0x6f01e045569d93c4, 0x40ac10dd049310b5,
+			[]uint64{0x60d24054e17a0698, 0xd5e79d89856e4f12, 0xd254972fe64bd782,
+				0xf1e3072a53c72571, 0xd7c1d7393d4115c9}},
+		{0x65c520459a603cbb, 0x330f5f0bbea440ff, 0x7e77d7adf0727810, 0x0a8857710c4874ed,
+			[]uint64{0x8e46425f5ffc712a, 0xba052b628c9570d7, 0x90e598b4c56545ef,
+				0x52ecb399742d50ca, 0xc89d426efcdd39c8}},
+	}
+	for _, tt := range tests {
+		r := pcg64.New(1)
+		r.SetLCGState(tt.stateHi, tt.stateLo, tt.incHi, tt.incLo)
+		for _, v := range tt.expected {
+			assert.Equal(v, r.Uint64())
+		}
+	}
+}
+
+func Test_PCG64_Advance(t *testing.T) {
+	assert := assert.New(t)
+
+	steps := []uint64{0, 1, 17, 1024, 674637}
+	for _, n := range steps {
+		r1 := pcg64.NewWithStream(20170612, 3)
+		r2 := pcg64.NewWithStream(20170612, 3)
+		r1.Advance(n)
+		for i := uint64(0); i < n; i++ {
+			_ = r2.Uint64()
+		}
+		assert.Equal(r2.Uint64(), r1.Uint64())
+	}
+}
+
+// Benchmarks
+func Benchmark_PCG64_Uint64(b *testing.B) {
+	rng := pcg64.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_PCG64_Float64(b *testing.B) {
+	rng := pcg64.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_PCG64_Float64OO(b *testing.B) {
+	rng := pcg64.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
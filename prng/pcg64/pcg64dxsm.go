@@ -0,0 +1,216 @@
+package pcg64
+
+import (
+	"encoding/gob"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+)
+
+var (
+	pcg64dxsm *PCG64DXSM
+	_         prng.Engine        = pcg64dxsm
+	_         prng.Advancer      = pcg64dxsm
+	_         prng.StateRestorer = pcg64dxsm
+)
+
+func init() {
+	prng.Register("pcg64dxsm", func(seed uint64) prng.Engine { return NewDXSM(seed) })
+	gob.Register(new(PCG64DXSM))
+}
+
+// cheapMultiplier is the 64-bit multiplier used by the LCG of PCG64DXSM and
+// by its output function
+const cheapMultiplier uint64 = 0xDA942042E4DD58B5
+
+// dxsmMultiplier is cheapMultiplier as a 128-bit multiplier
+var dxsmMultiplier = uint128{0, cheapMultiplier}
+
+// PCG64DXSM implements the PCG DXSM 128/64 generator: a 128-bit LCG with a
+// 64-bit multiplier, whose output is the "double xorshift multiply"
+// permutation of the state before it is advanced. The period of each stream
+// is 2^128.
+type PCG64DXSM struct {
+	seed   uint64
+	stream uint64
+	lcg
+}
+
+// NewDXSM returns a new instance of the PCG64DXSM PRNG Engine using
+// stream 0.
+// If the seed provided is 0, the engine is initialized with current time
+func NewDXSM(seed uint64) *PCG64DXSM {
+	return NewDXSMWithStream(seed, 0)
+}
+
+// NewDXSMWithStream returns a new instance of the PCG64DXSM PRNG Engine
+// using the given stream. Engines with the same seed but different streams
+// generate different sequences.
+// If the seed provided is 0, the engine is initialized with current time
+func NewDXSMWithStream(seed uint64, stream uint64) *PCG64DXSM {
+	r := new(PCG64DXSM)
+	r.stream = stream
+	r.Seed(seed)
+	return r
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64.
+// Uint64 advances the internal state of the engine.
+func (p *PCG64DXSM) Uint64() uint64 {
+	hi := p.state.hi
+	lo := p.state.lo | 1
+	hi ^= hi >> 32
+	hi *= cheapMultiplier
+	hi ^= hi >> 48
+	hi *= lo
+	p.step(dxsmMultiplier)
+	return hi
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (p *PCG64DXSM) Float64() float64 {
+	return float64(p.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (p *PCG64DXSM) Float64OO() float64 {
+	return (float64(p.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine on its current
+// stream. If the seed provided is 0, the engine is initialized with current
+// time
+func (p *PCG64DXSM) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	p.seed = seed
+	p.srandom(uint128{0, seed}, uint128{0, p.stream}, dxsmMultiplier)
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (p *PCG64DXSM) GetSeed() uint64 { return p.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (p *PCG64DXSM) GetState() []byte {
+	return state.Encode("pcg64dxsm", p.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (p *PCG64DXSM) fields() []interface{} {
+	return []interface{}{
+		uint64(p.seed),
+		uint64(p.stream),
+		uint64(p.state.hi),
+		uint64(p.state.lo),
+		uint64(p.inc.hi),
+		uint64(p.inc.lo),
+	}
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (p *PCG64DXSM) SetState(b []byte) {
+	if err := p.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of PCG64DXSM, e.g. if it is truncated or if its
+// increment is even
+func (p *PCG64DXSM) SetStateE(b []byte) error {
+	return p.setState(state.NewDecoder("pcg64dxsm", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (p *PCG64DXSM) setState(d *state.Decoder) error {
+	seed, stream, g, err := decodeState(d)
+	if err != nil {
+		return err
+	}
+	p.seed, p.stream, p.lcg = seed, stream, g
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed and the stream
+func (p *PCG64DXSM) Reset() {
+	p.Seed(p.seed)
+}
+
+// Advance advances the internal state of the engine by n steps, i.e. it is
+// equivalent to calling Uint64 n times, in time proportional to log(n)
+func (p *PCG64DXSM) Advance(n uint64) {
+	p.advance(n, dxsmMultiplier)
+}
+
+// GetStream returns the stream of the engine
+func (p *PCG64DXSM) GetStream() uint64 { return p.stream }
+
+// SetStream selects the stream of the engine and re-initializes the engine
+// with its current seed on the new stream
+func (p *PCG64DXSM) SetStream(stream uint64) {
+	p.stream = stream
+	p.Seed(p.seed)
+}
+
+// SetLCGState sets the 128-bit state and increment of the underlying LCG,
+// given as their most and least significant 64 bits. The increment is made
+// odd by setting its least significant bit.
+//
+// The engine then continues the stream of any PCG64DXSM implementation with
+// the same LCG state, e.g. of numpy.random.PCG64DXSM whose state is
+// available as bit_generator.state["state"]. Seed and stream are left unchanged, hence
+// Reset reverts to the seeded state.
+func (p *PCG64DXSM) SetLCGState(stateHi, stateLo, incHi, incLo uint64) {
+	p.state = uint128{stateHi, stateLo}
+	p.inc = uint128{incHi, incLo | 1}
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (p *PCG64DXSM) MarshalBinary() ([]byte, error) { return p.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (p *PCG64DXSM) UnmarshalBinary(b []byte) error { return p.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (p *PCG64DXSM) MarshalText() ([]byte, error) {
+	return state.MarshalText(p.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (p *PCG64DXSM) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("pcg64dxsm", text)
+	if err != nil {
+		return err
+	}
+	return p.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (p *PCG64DXSM) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("pcg64dxsm", p.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (p *PCG64DXSM) UnmarshalJSON(data []byte) error {
+	return p.setState(state.NewJSONDecoder("pcg64dxsm", data))
+}
@@ -0,0 +1,200 @@
+package pcg64_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/pcg64"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadirDXSM = filepath.Join("..", "..", "data", "pcg64dxsm")
+
+func Test_PCG64DXSM_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := pcg64.NewDXSM(0)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+}
+
+func Test_PCG64DXSM_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed and stream remain same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := pcg64.NewDXSMWithStream(seed, seed+1)
+		states[i] = r.GetState()
+	}
+	for i, state := range states {
+		r := pcg64.NewDXSM(0)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+		assert.Equal(seeds[i]+1, r.GetStream())
+	}
+
+	// Checking that the streams remain same after getting and setting states
+	r1 := pcg64.NewDXSMWithStream(0, 7)
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+	}
+	r2 := pcg64.NewDXSM(0)
+	r2.SetState(r1.GetState())
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+		_ = r2.Uint64()
+	}
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	assert.Equal(r1.Float64(), r2.Float64())
+	assert.Equal(r1.Float64OO(), r2.Float64OO())
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := pcg64.NewDXSM(0)
+		r1.SetState([]byte("Hello"))
+	})
+	assert.Panics(func() {
+		r1 := pcg64.NewDXSM(0)
+		r1.SetState(nil)
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("pcg64dxsn"))
+	assert.Panics(func() {
+		r1 := pcg64.NewDXSM(0)
+		r1.SetState(buf.Bytes())
+	})
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("pcg64dxsm"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := pcg64.NewDXSM(0)
+		r1.SetState(buf.Bytes())
+	})
+}
+
+func Test_PCG64DXSM_Uint64(t *testing.T) {
+	e := pcg64.NewDXSM(0)
+	filenames := prngtest.GetDataFiles(datadirDXSM, "*uint64*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_PCG64DXSM_Float64(t *testing.T) {
+	e := pcg64.NewDXSM(0)
+	filenames := prngtest.GetDataFiles(datadirDXSM, "*float64*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_PCG64DXSM_Float64OO(t *testing.T) {
+	e := pcg64.NewDXSM(0)
+	filenames := prngtest.GetDataFiles(datadirDXSM, "*float64oo*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_PCG64DXSM_Stream(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking that streams differ and that SetStream re-seeds the engine
+	r1 := pcg64.NewDXSMWithStream(42, 54)
+	r2 := pcg64.NewDXSM(42)
+	v := r1.Uint64()
+	assert.NotEqual(v, r2.Uint64())
+	r2.SetStream(54)
+	assert.Equal(uint64(54), r2.GetStream())
+	assert.Equal(v, r2.Uint64())
+
+	// Expected values from the DXSM output function and cheap-multiplier
+	// LCG step of NumPy's pcg64.h for the given LCG state and increment
+	expected := []uint64{11944377826318632098, 3821531367282631979,
+		4024874779112981246, 2580867437274456663, 3849152479257589067}
+	r := pcg64.NewDXSM(1)
+	r.SetLCGState(0x0123456789abcdef, 0xfedcba9876543210,
+		0x1111111111111111, 0x2222222222222222)
+	for _, v := range expected {
+		assert.Equal(v, r.Uint64())
+	}
+	r.Reset()
+	assert.Equal(pcg64.NewDXSM(1).Uint64(), r.Uint64())
+}
+
+func Test_PCG64DXSM_NumPy(t *testing.T) {
+	assert := assert.New(t)
+
+	// bit_generator.state of numpy.random.PCG64DXSM(seed) for the seeds
+	// 38219308213743 and 0xdeadbeaf, and the state after advance(2**96 +
+	// 2**64 + 2**32 + 2**16 + 2**8 + 2**4 + 2**2 + 2**1) from the first one,
+	// as asserted by test_advange_large in numpy/random/tests/test_direct.py,
+	// followed by the first values of random_raw(). The values for
+	// 0xdeadbeaf start as in pcg64dxsm-testset-1.csv of NumPy.
+	tests := []struct {
+		stateHi, stateLo, incHi, incLo uint64
+		expected                       []uint64
+	}{
+		{0xd85f72c717ad8f6c, 0x6962e34f5a12f2cd, 0x7e77d7adf0727810, 0x0a8857710c4874ed,
+			[]uint64{0xcd8ffb8ca10e18d9, 0xb527f50f28393939, 0x8eb175f0b628f0df,
+				0x04aa90abcecc2a64, 0x97704704d35ad918}},
+		{0xdcbf51eca8a06d6d, 0xa11c0e6f95be4591, 0x6f01e045569d93c4, 0x40ac10dd049310b5,
+			[]uint64{0xdf1ddcf1e22521fe, 0xc71b2f9c706cf151, 0x6922a8cc24ad96b2,
+				0x82738c549beccc30, 0x5e8415cdb1f17580}},
+		{0xd0fa1ce6dec18cf6, 0xd3aab6e704c1466f, 0x7e77d7adf0727810, 0x0a8857710c4874ed,
+			[]uint64{0xdddc32591f120ef7, 0xf8c09c545a3bd6cf, 0x5ea3193eef2896bd,
+				0xa0e7a6e76aa38527, 0x86f3696a5e35a0d8}},
+	}
+	for _, tt := range tests {
+		r := pcg64.NewDXSM(1)
+		r.SetLCGState(tt.stateHi, tt.stateLo, tt.incHi, tt.incLo)
+		for _, v := range tt.expected {
+			assert.Equal(v, r.Uint64())
+		}
+	}
+}
+
+func Test_PCG64DXSM_Advance(t *testing.T) {
+	assert := assert.New(t)
+
+	steps := []uint64{0, 1, 17, 1024, 674637}
+	for _, n := range steps {
+		r1 := pcg64.NewDXSMWithStream(20170612, 3)
+		r2 := pcg64.NewDXSMWithStream(20170612, 3)
+		r1.Advance(n)
+		for i := uint64(0); i < n; i++ {
+			_ = r2.Uint64()
+		}
+		assert.Equal(r2.Uint64(), r1.Uint64())
+	}
+}
+
+// Benchmarks
+func Benchmark_PCG64DXSM_Uint64(b *testing.B) {
+	rng := pcg64.NewDXSM(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_PCG64DXSM_Float64(b *testing.B) {
+	rng := pcg64.NewDXSM(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_PCG64DXSM_Float64OO(b *testing.B) {
+	rng := pcg64.NewDXSM(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
@@ -0,0 +1,78 @@
+package pcg64
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/shivakar/random/prng/internal/state"
+)
+
+// uint128 is an unsigned 128-bit integer
+type uint128 struct {
+	hi, lo uint64
+}
+
+// add returns a+b modulo 2^128
+func (a uint128) add(b uint128) uint128 {
+	lo, carry := bits.Add64(a.lo, b.lo, 0)
+	hi, _ := bits.Add64(a.hi, b.hi, carry)
+	return uint128{hi, lo}
+}
+
+// mul returns a*b modulo 2^128
+func (a uint128) mul(b uint128) uint128 {
+	hi, lo := bits.Mul64(a.lo, b.lo)
+	hi += a.hi*b.lo + a.lo*b.hi
+	return uint128{hi, lo}
+}
+
+// lcg is a 128-bit linear congruential generator, state = state*mult + inc
+type lcg struct {
+	state uint128
+	inc   uint128
+}
+
+// step advances the state of the generator by one step
+func (g *lcg) step(mult uint128) {
+	g.state = g.state.mul(mult).add(g.inc)
+}
+
+// srandom initializes the generator as pcg_setseq_128_srandom_r of the
+// reference implementation
+func (g *lcg) srandom(initstate, initseq uint128, mult uint128) {
+	g.state = uint128{}
+	g.inc = uint128{initseq.hi<<1 | initseq.lo>>63, initseq.lo<<1 | 1}
+	g.step(mult)
+	g.state = g.state.add(initstate)
+	g.step(mult)
+}
+
+// advance advances the state of the generator by delta steps in
+// O(log(delta)) operations using the algorithm by F. B. Brown
+func (g *lcg) advance(delta uint64, mult uint128) {
+	one := uint128{0, 1}
+	accMult, accPlus := one, uint128{}
+	curMult, curPlus := mult, g.inc
+	for ; delta > 0; delta >>= 1 {
+		if delta&1 != 0 {
+			accMult = accMult.mul(curMult)
+			accPlus = accPlus.mul(curMult).add(curPlus)
+		}
+		curPlus = curMult.add(one).mul(curPlus)
+		curMult = curMult.mul(curMult)
+	}
+	g.state = accMult.mul(g.state).add(accPlus)
+}
+
+// decodeState returns the seed, the stream and the generator decoded by d,
+// or an error if the state is invalid or if the increment of the generator
+// is even
+func decodeState(d *state.Decoder) (uint64, uint64, lcg, error) {
+	var seed, stream uint64
+	var g lcg
+	d.Read(&seed, &stream, &g.state.hi, &g.state.lo, &g.inc.hi, &g.inc.lo)
+	if g.inc.lo&1 == 0 {
+		d.Fail(fmt.Errorf("Increment must be odd"))
+	}
+	return seed, stream, g, d.Err()
+}
@@ -0,0 +1,35 @@
+package philox
+
+import "math/bits"
+
+// Rounds is the number of rounds of the Philox4x64 bijection used by the
+// engine and by At, as recommended by Random123
+const Rounds = 10
+
+// Multipliers and Weyl sequence increments of the key schedule of
+// Philox4x64
+const (
+	m0 = 0xD2E7470EE14C6C93
+	m1 = 0xCA5A826395121157
+	w0 = 0x9E3779B97F4A7C15
+	w1 = 0xBB67AE8584CAA73B
+)
+
+// At returns the output of Philox4x64-10 for the given key and counter.
+// At has no state: the same key and counter always yield the same output,
+// which is the block generated by an engine with that key when its counter
+// equals counter.
+func At(key [2]uint64, counter [4]uint64) [4]uint64 {
+	x := counter
+	k0, k1 := key[0], key[1]
+	for i := 0; i < Rounds; i++ {
+		if i > 0 {
+			k0 += w0
+			k1 += w1
+		}
+		hi0, lo0 := bits.Mul64(m0, x[0])
+		hi1, lo1 := bits.Mul64(m1, x[2])
+		x = [4]uint64{hi1 ^ x[1] ^ k0, lo1, hi0 ^ x[3] ^ k1, lo0}
+	}
+	return x
+}
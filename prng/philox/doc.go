@@ -0,0 +1,22 @@
+// Package philox implements the counter-based PRNG Philox4x64-10 of the
+// Random123 library.
+//
+// Philox4x64 applies a keyed bijection, made of 10 rounds of 64-bit
+// multiplications and xors, to a 256-bit counter. The output for any
+// (key, counter) pair is available without sequential state through At,
+// e.g. to draw the numbers of a work item in a parallel computation, or of
+// a GPU thread, directly from its index. The engine Philox4x64 draws the
+// four values of At(key, counter) and then increments the counter; Seek
+// and Advance (prng.Advancer) move it to any position in constant time.
+//
+// Philox4x64-10 is Crush-resistant, i.e. it passes BigCrush with any key.
+// It is not designed to be cryptographically secure.
+//
+// References:
+//
+// J. K. Salmon, M. A. Moraes, R. O. Dror, D. E. Shaw, "Parallel Random
+// Numbers: As Easy as 1, 2, 3", Proceedings of SC11 (2011).
+//
+// https://www.deshawresearch.com/resources_random123.html
+// https://github.com/DEShawResearch/random123
+package philox
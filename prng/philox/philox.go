@@ -0,0 +1,245 @@
+package philox
+
+import (
+	"encoding/gob"
+	"fmt"
+	"math/bits"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+var (
+	philox *Philox4x64
+	_      prng.Engine        = philox
+	_      prng.Advancer      = philox
+	_      prng.StateRestorer = philox
+)
+
+func init() {
+	prng.Register("philox4x64", func(seed uint64) prng.Engine { return New(seed) })
+	gob.Register(new(Philox4x64))
+}
+
+// Philox4x64 implements a counter-based PRNG: the output for a 256-bit
+// counter is the block At(key, counter) of four uint64 values, and the
+// engine increments the counter after every block. The period for each
+// 128-bit key is 2^258.
+type Philox4x64 struct {
+	seed    uint64
+	key     [2]uint64
+	counter [4]uint64
+	index   int
+	buf     [4]uint64
+	filled  bool
+}
+
+// New returns a new instance of the Philox4x64 PRNG Engine, with the key
+// derived from the seed using SplitMix64.
+// If the seed provided is 0, the engine is initialized with current time
+func New(seed uint64) *Philox4x64 {
+	r := new(Philox4x64)
+	r.Seed(seed)
+	return r
+}
+
+// NewWithKey returns a new instance of the Philox4x64 PRNG Engine using the
+// given key, with its counter at 0. GetSeed returns 0 for engines created by
+// NewWithKey.
+func NewWithKey(key [2]uint64) *Philox4x64 {
+	r := new(Philox4x64)
+	r.SetKey(key)
+	return r
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64.
+// Uint64 advances the internal state of the engine.
+func (p *Philox4x64) Uint64() uint64 {
+	if !p.filled {
+		p.buf = At(p.key, p.counter)
+		p.filled = true
+	}
+	v := p.buf[p.index]
+	p.index++
+	if p.index == len(p.buf) {
+		p.Seek(add(p.counter, 1))
+	}
+	return v
+}
+
+// add returns the 256-bit sum c + n, modulo 2^256
+func add(c [4]uint64, n uint64) [4]uint64 {
+	var carry uint64
+	c[0], carry = bits.Add64(c[0], n, 0)
+	for i := 1; i < len(c); i++ {
+		c[i], carry = bits.Add64(c[i], 0, carry)
+	}
+	return c
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (p *Philox4x64) Float64() float64 {
+	return float64(p.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (p *Philox4x64) Float64OO() float64 {
+	return (float64(p.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine. The key is made of
+// two consecutive outputs of SplitMix64 seeded with seed and the counter is
+// set to 0.
+// If the seed provided is 0, the engine is initialized with current time
+func (p *Philox4x64) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	p.seed = seed
+	ms := splitmix64.New(seed)
+	for i := range p.key {
+		p.key[i] = ms.Uint64()
+	}
+	p.Seek([4]uint64{})
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (p *Philox4x64) GetSeed() uint64 { return p.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (p *Philox4x64) GetState() []byte {
+	return state.Encode("philox4x64", p.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (p *Philox4x64) fields() []interface{} {
+	return []interface{}{
+		uint64(p.seed),
+		p.key,
+		p.counter,
+		uint64(p.index),
+	}
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (p *Philox4x64) SetState(b []byte) {
+	if err := p.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of Philox4x64, e.g. if it is truncated or if
+// its position is beyond the end of a block
+func (p *Philox4x64) SetStateE(b []byte) error {
+	return p.setState(state.NewDecoder("philox4x64", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (p *Philox4x64) setState(d *state.Decoder) error {
+	var seed, index uint64
+	var key [2]uint64
+	var counter [4]uint64
+	d.Read(&seed, &key, &counter, &index)
+	if index >= uint64(len(p.buf)) {
+		d.Fail(fmt.Errorf("Invalid position %d in block", index))
+	}
+	if err := d.Err(); err != nil {
+		return err
+	}
+	p.seed = seed
+	p.key = key
+	p.Seek(counter)
+	p.index = int(index)
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// i.e. sets the counter to 0, keeping the seed and the key
+func (p *Philox4x64) Reset() {
+	p.Seek([4]uint64{})
+}
+
+// Advance advances the internal state of the engine by n steps, i.e. it is
+// equivalent to calling Uint64 n times, in constant time
+func (p *Philox4x64) Advance(n uint64) {
+	blocks := n / uint64(len(p.buf))
+	index := p.index + int(n%uint64(len(p.buf)))
+	if index >= len(p.buf) {
+		blocks++
+		index -= len(p.buf)
+	}
+	p.Seek(add(p.counter, blocks))
+	p.index = index
+}
+
+// Seek sets the counter of the engine, i.e. the next call to Uint64 returns
+// the first value of At(key, counter)
+func (p *Philox4x64) Seek(counter [4]uint64) {
+	p.counter = counter
+	p.index = 0
+	p.filled = false
+}
+
+// Counter returns the counter of the block the next value is drawn from
+func (p *Philox4x64) Counter() [4]uint64 { return p.counter }
+
+// Key returns the key of the engine
+func (p *Philox4x64) Key() [2]uint64 { return p.key }
+
+// SetKey sets the key of the engine and its counter to 0. The seed is left
+// unchanged: Reset keeps the key, whereas Seed replaces it with the key
+// derived from the new seed.
+func (p *Philox4x64) SetKey(key [2]uint64) {
+	p.key = key
+	p.Seek([4]uint64{})
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (p *Philox4x64) MarshalBinary() ([]byte, error) { return p.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (p *Philox4x64) UnmarshalBinary(b []byte) error { return p.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (p *Philox4x64) MarshalText() ([]byte, error) {
+	return state.MarshalText(p.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (p *Philox4x64) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("philox4x64", text)
+	if err != nil {
+		return err
+	}
+	return p.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (p *Philox4x64) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("philox4x64", p.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (p *Philox4x64) UnmarshalJSON(data []byte) error {
+	return p.setState(state.NewJSONDecoder("philox4x64", data))
+}
@@ -0,0 +1,263 @@
+package philox_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/philox"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadir = filepath.Join("..", "..", "data", "philox")
+var longTest bool
+
+// TestMain is the entry point for testing
+func TestMain(m *testing.M) {
+	longTest = prngtest.ParseCommandLine()
+	os.Exit(m.Run())
+}
+
+func Test_Philox4x64_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := philox.New(0)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+}
+
+func Test_Philox4x64_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed remains same after getting and setting states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := philox.New(seed)
+		states[i] = r.GetState()
+	}
+	for i, state := range states {
+		r := philox.New(0)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+	}
+
+	// Checking that the streams remain same after getting and setting
+	// states, at the start of a block and within a block
+	for _, n := range []int{0, 3, 4, 13} {
+		r1 := philox.New(0)
+		for i := 0; i < n; i++ {
+			_ = r1.Uint64()
+		}
+		r2 := philox.New(0)
+		r2.SetState(r1.GetState())
+		for i := 0; i < 10; i++ {
+			assert.Equal(r1.Uint64(), r2.Uint64())
+		}
+		assert.Equal(r1.Float64(), r2.Float64())
+		assert.Equal(r1.Float64OO(), r2.Float64OO())
+	}
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := philox.New(0)
+		r1.SetState([]byte("Hello"))
+	})
+	assert.Panics(func() {
+		r1 := philox.New(0)
+		r1.SetState(nil)
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("philox2x64"))
+	assert.Panics(func() {
+		r1 := philox.New(0)
+		r1.SetState(buf.Bytes())
+	})
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("philox4x64"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, [2]uint64{})
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := philox.New(0)
+		r1.SetState(buf.Bytes())
+	})
+
+	// Position beyond the end of a block
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("philox4x64"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, [2]uint64{})
+	_ = binary.Write(buf, binary.LittleEndian, [4]uint64{})
+	_ = binary.Write(buf, binary.LittleEndian, uint64(4))
+	assert.Panics(func() {
+		r1 := philox.New(0)
+		r1.SetState(buf.Bytes())
+	})
+}
+
+func Test_Philox4x64_Uint64(t *testing.T) {
+	e := philox.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "*uint64*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_Philox4x64_Float64(t *testing.T) {
+	e := philox.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "*float64*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_Philox4x64_Float64OO(t *testing.T) {
+	e := philox.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "*float64oo*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_Philox4x64_KnownAnswers(t *testing.T) {
+	assert := assert.New(t)
+
+	// Known-answer tests for philox4x64 with 10 rounds from kat_vectors of
+	// Random123
+	f := ^uint64(0)
+	tests := []struct {
+		key      [2]uint64
+		counter  [4]uint64
+		expected [4]uint64
+	}{
+		{[2]uint64{0, 0}, [4]uint64{0, 0, 0, 0},
+			[4]uint64{0x16554d9eca36314c, 0xdb20fe9d672d0fdc,
+				0xd7e772cee186176b, 0x7e68b68aec7ba23b}},
+		{[2]uint64{f, f}, [4]uint64{f, f, f, f},
+			[4]uint64{0x87b092c3013fe90b, 0x438c3c67be8d0224,
+				0x9cc7d7c69cd777b6, 0xa09caebf594f0ba0}},
+		{[2]uint64{0x452821e638d01377, 0xbe5466cf34e90c6c},
+			[4]uint64{0x243f6a8885a308d3, 0x13198a2e03707344,
+				0xa4093822299f31d0, 0x082efa98ec4e6c89},
+			[4]uint64{0xa528f45403e61d95, 0x38c72dbd566e9788,
+				0xa5a1610e72fd18b5, 0x57bd43b5e52b7fe6}},
+	}
+	for _, test := range tests {
+		assert.Equal(test.expected, philox.At(test.key, test.counter))
+
+		r := philox.NewWithKey(test.key)
+		assert.Zero(r.GetSeed())
+		assert.Equal(test.key, r.Key())
+		r.Seek(test.counter)
+		for _, v := range test.expected {
+			assert.Equal(v, r.Uint64())
+		}
+	}
+}
+
+func Test_Philox4x64_AdvanceSeek(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking Advance against drawing, from every position within a block
+	steps := []uint64{0, 1, 3, 4, 5, 17, 1024, 674637}
+	for _, n := range steps {
+		for offset := 0; offset < 4; offset++ {
+			r1 := philox.New(20170612)
+			r2 := philox.New(20170612)
+			for i := 0; i < offset; i++ {
+				_ = r1.Uint64()
+				_ = r2.Uint64()
+			}
+			r1.Advance(n)
+			for i := uint64(0); i < n; i++ {
+				_ = r2.Uint64()
+			}
+			assert.Equal(r2.Counter(), r1.Counter())
+			assert.Equal(r2.Uint64(), r1.Uint64())
+		}
+	}
+
+	// Checking that the counter carries into its upper words
+	f := ^uint64(0)
+	r := philox.New(20170612)
+	r.Seek([4]uint64{f, f, 0, 0})
+	r.Advance(5)
+	assert.Equal([4]uint64{0, 0, 1, 0}, r.Counter())
+	r.Seek([4]uint64{f, f, f, f})
+	r.Advance(4)
+	assert.Equal([4]uint64{}, r.Counter())
+
+	// Checking that Reset and SetKey rewind the engine
+	r1 := philox.New(20170612)
+	v := r1.Uint64()
+	r1.Advance(100)
+	r1.Reset()
+	assert.Equal(v, r1.Uint64())
+	r2 := philox.New(1)
+	r2.SetKey(r1.Key())
+	assert.Equal(uint64(1), r2.GetSeed())
+	assert.Equal(v, r2.Uint64())
+}
+
+// Benchmarks
+func Benchmark_Philox4x64_Uint64(b *testing.B) {
+	rng := philox.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_Philox4x64_Float64(b *testing.B) {
+	rng := philox.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_Philox4x64_Float64OO(b *testing.B) {
+	rng := philox.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+func Benchmark_Philox4x64_At(b *testing.B) {
+	key := [2]uint64{1, 2}
+	for i := 0; i < b.N; i++ {
+		_ = philox.At(key, [4]uint64{uint64(i), 0, 0, 0})
+	}
+}
+
+// Example - Philox4x64 Usage
+func ExamplePhilox4x64() {
+	// Create a new instance of the Philox4x64 engine
+	r := philox.New(20170612)
+
+	fmt.Println("Philox4x64: seed = 20170612; Uint64()")
+	for i := 0; i < 3; i++ {
+		// Draw 64 random bits as a uint64
+		fmt.Println(r.Uint64())
+	}
+
+	// Output:
+	// Philox4x64: seed = 20170612; Uint64()
+	// 8000115559804084397
+	// 9276545546683602975
+	// 14886064354784183896
+}
+
+// Example - Stateless use of Philox4x64
+func ExampleAt() {
+	// The output only depends on the key and the counter, e.g. the
+	// index of a work item in a parallel computation
+	key := [2]uint64{20170612, 0}
+	fmt.Println(philox.At(key, [4]uint64{42, 0, 0, 0})[0])
+
+	// Output:
+	// 18004211714871047023
+}
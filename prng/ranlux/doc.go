@@ -0,0 +1,38 @@
+// Package ranlux implements the RANLUX pseudo-random number generators of
+// Lüscher, with the parameters of the C++ standard library:
+//
+//   - Ranlux24 uses the subtract-with-carry engine on 24-bit words with lags
+//     10 and 24 of std::ranlux24_base, and returns 23 values per block
+//   - Ranlux48 uses the subtract-with-carry engine on 48-bit words with lags
+//     5 and 12 of std::ranlux48_base, and returns 11 values per block
+//
+// A subtract-with-carry engine alone has strong correlations between its
+// values. Lüscher showed that its recurrence is chaotic, so that values
+// sufficiently far apart in the sequence are decorrelated, and RANLUX
+// therefore discards the end of each block of p values. The luxury level
+// selects p:
+//
+//	level   0    1    2    3    4
+//	p      24   48   97  223  389
+//
+// Higher luxury levels give a stronger decorrelation guarantee at a
+// proportionally higher cost. Ranlux24 at level 3 is std::ranlux24 and
+// Ranlux48 at level 4 is std::ranlux48: for any non-zero seed, Uint24 and
+// Uint48 return the values of the C++ engines constructed with that seed.
+//
+// Uint64 is made of the bits of consecutive values of Uint24 or Uint48,
+// most significant first.
+//
+// References:
+//
+// G. Marsaglia and A. Zaman, "A New Class of Random Number Generators",
+// The Annals of Applied Probability 1(3) (1991) 462--480.
+//
+// M. Lüscher, "A portable high-quality random number generator for lattice
+// field theory simulations", Computer Physics Communications 79 (1994)
+// 100--110.
+//
+// F. James, "RANLUX: A Fortran implementation of the high-quality
+// pseudorandom number generator of Lüscher", Computer Physics
+// Communications 79 (1994) 111--114.
+package ranlux
@@ -0,0 +1,169 @@
+package ranlux
+
+import (
+	"fmt"
+
+	"github.com/shivakar/random/prng/internal/state"
+)
+
+// blockSizes are the block sizes p of the luxury levels 0 to 4: of each
+// block of p values of the subtract-with-carry engine, only the first ones
+// are returned and the others are discarded
+var blockSizes = [...]int{24, 48, 97, 223, 389}
+
+// Parameters of the linear congruential generator used by
+// std::subtract_with_carry_engine to derive its state from a seed
+const (
+	lcgA uint64 = 40014
+	lcgM uint64 = 2147483563
+)
+
+// swc implements the subtract-with-carry engine
+// x(i) = (x(i-s) - x(i-r) - c) mod 2^w of Marsaglia and Zaman, as
+// std::subtract_with_carry_engine, followed by the discard block of a luxury
+// level, as std::discard_block_engine
+type swc struct {
+	w     uint // word size in bits
+	s, r  int  // short and long lags
+	used  int  // number of values returned per block
+	level int
+	x     [24]uint64
+	carry uint64
+	i     int // index of x(i-r) in x
+	n     int // number of values returned from the current block
+}
+
+// setLevel sets the luxury level, panicking if it is not supported
+func (g *swc) setLevel(level int) {
+	if level < 0 || level >= len(blockSizes) {
+		panic(fmt.Sprintf("ranlux: Unsupported luxury level %d", level))
+	}
+	g.level = level
+}
+
+// seed initializes the state from seed as std::subtract_with_carry_engine,
+// with the values of a linear congruential generator modulo 2147483563
+// seeded with seed, and starts a new block
+func (g *swc) seed(seed uint64) {
+	lcg := seed % lcgM
+	if lcg == 0 {
+		lcg = 1
+	}
+	for k := 0; k < g.r; k++ {
+		var v uint64
+		for j := uint(0); j < g.w; j += 32 {
+			lcg = lcgA * lcg % lcgM
+			v |= lcg << j
+		}
+		g.x[k] = v & (1<<g.w - 1)
+	}
+	g.carry = 0
+	if g.x[g.r-1] == 0 {
+		g.carry = 1
+	}
+	g.i = 0
+	g.n = 0
+}
+
+// step returns the next value of the subtract-with-carry engine
+func (g *swc) step() uint64 {
+	ps := g.i - g.s
+	if ps < 0 {
+		ps += g.r
+	}
+	xs, xr := g.x[ps], g.x[g.i]
+	var v uint64
+	if xs >= xr+g.carry {
+		v = xs - xr - g.carry
+		g.carry = 0
+	} else {
+		v = 1<<g.w - xr - g.carry + xs
+		g.carry = 1
+	}
+	g.x[g.i] = v
+	g.i++
+	if g.i == g.r {
+		g.i = 0
+	}
+	return v
+}
+
+// next returns the next value of the engine, discarding the end of the
+// current block when all its used values have been returned
+func (g *swc) next() uint64 {
+	if g.n >= g.used {
+		for ; g.n < blockSizes[g.level]; g.n++ {
+			_ = g.step()
+		}
+		g.n = 0
+	}
+	g.n++
+	return g.step()
+}
+
+// stateFields returns the fields of the state of a RANLUX engine: the seed, the luxury level, the words, the carry, the
+// index of x(i-r) and the position in the current block
+func stateFields(seed uint64, g *swc) []interface{} {
+	return []interface{}{
+		uint64(seed),
+		uint64(g.level),
+		g.x[:g.r],
+		uint64(g.carry),
+		uint64(g.i),
+		uint64(g.n),
+	}
+}
+
+// decodeState decodes the fields of stateFields by d into g, checks them and
+// returns the seed, or an error if the state is invalid
+func decodeState(d *state.Decoder, g *swc) (uint64, error) {
+	var seed, level, carry, i, n uint64
+	x := make([]uint64, g.r)
+	d.Read(&seed, &level, x, &carry, &i, &n)
+	if err := checkState(g, level, x, carry, i, n); err != nil {
+		d.Fail(err)
+	}
+	if err := d.Err(); err != nil {
+		return 0, err
+	}
+	g.level = int(level)
+	copy(g.x[:], x)
+	g.carry = carry
+	g.i = int(i)
+	g.n = int(n)
+	return seed, nil
+}
+
+// checkState checks that the decoded fields are a valid state of g. Besides
+// the ranges of the fields, the two fixed points of the recurrence, all
+// words 0 with no carry and all words 2^w-1 with a carry, are rejected.
+func checkState(g *swc, level uint64, x []uint64, carry, i, n uint64) error {
+	if level >= uint64(len(blockSizes)) {
+		return fmt.Errorf("Unsupported luxury level %d", level)
+	}
+	if carry > 1 {
+		return fmt.Errorf("Invalid carry %d", carry)
+	}
+	if i >= uint64(g.r) {
+		return fmt.Errorf("Invalid index %d", i)
+	}
+	if n > uint64(g.used) {
+		return fmt.Errorf("Invalid position %d in block", n)
+	}
+	zeros, ones := 0, 0
+	for k, v := range x {
+		if v>>g.w != 0 {
+			return fmt.Errorf("Word %d = %d is not in [0, 2^%d)", k, v, g.w)
+		}
+		if v == 0 {
+			zeros++
+		}
+		if v == 1<<g.w-1 {
+			ones++
+		}
+	}
+	if (zeros == g.r && carry == 0) || (ones == g.r && carry == 1) {
+		return fmt.Errorf("State is a fixed point of the engine")
+	}
+	return nil
+}
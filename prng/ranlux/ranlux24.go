@@ -0,0 +1,178 @@
+package ranlux
+
+import (
+	"encoding/gob"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+)
+
+var (
+	ranlux24 *Ranlux24
+	_        prng.Engine        = ranlux24
+	_        prng.StateRestorer = ranlux24
+)
+
+func init() {
+	prng.Register("ranlux24", func(seed uint64) prng.Engine { return New24(seed, 3) })
+	gob.Register(new(Ranlux24))
+}
+
+// Ranlux24 implements the RANLUX PRNG of Lüscher on 24-bit words, i.e. the
+// subtract-with-carry engine with lags 10 and 24 of std::ranlux24_base, of
+// which 23 values are returned per block of 24, 48, 97, 223 or 389 values
+// depending on the luxury level
+type Ranlux24 struct {
+	seed uint64
+	g    swc
+}
+
+// base24 is the subtract-with-carry engine of Ranlux24, before setting its
+// luxury level and seeding it
+var base24 = swc{w: 24, s: 10, r: 24, used: 23}
+
+// New24 returns a new instance of the Ranlux24 PRNG Engine with the given
+// luxury level, which must be in [0, 4]. At luxury level 3, the engine is
+// std::ranlux24.
+// If the seed provided is 0, the engine is initialized with current time
+func New24(seed uint64, level int) *Ranlux24 {
+	r := &Ranlux24{g: base24}
+	r.g.setLevel(level)
+	r.Seed(seed)
+	return r
+}
+
+// Level returns the luxury level of the engine
+func (r *Ranlux24) Level() int { return r.g.level }
+
+// Uint24 returns a pseudo-random 24-bit value in [0, 2^24) as a uint32,
+// i.e. the output of std::ranlux24 at luxury level 3.
+// Uint24 advances the internal state of the engine.
+func (r *Ranlux24) Uint24() uint32 {
+	return uint32(r.g.next())
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64,
+// made of the 24 bits of a value of Uint24, the 24 bits of the next one and
+// the 16 most significant bits of the third one.
+// Uint64 advances the internal state of the engine.
+func (r *Ranlux24) Uint64() uint64 {
+	a := r.g.next()
+	b := r.g.next()
+	return a<<40 | b<<16 | r.g.next()>>8
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (r *Ranlux24) Float64() float64 {
+	return float64(r.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (r *Ranlux24) Float64OO() float64 {
+	return (float64(r.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine as
+// std::ranlux24_base, so that seeds congruent modulo 2147483563 yield the
+// same sequence.
+// If the seed provided is 0, the engine is initialized with current time
+func (r *Ranlux24) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	r.seed = seed
+	r.g.seed(seed)
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (r *Ranlux24) GetSeed() uint64 { return r.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (r *Ranlux24) GetState() []byte {
+	return state.Encode("ranlux24", r.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (r *Ranlux24) fields() []interface{} {
+	return stateFields(r.seed, &r.g)
+}
+
+// SetState sets the internal state of the engine, including its luxury
+// level, from a []byte
+// SetState can be used to resume from a saved state
+func (r *Ranlux24) SetState(b []byte) {
+	if err := r.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine, including its luxury
+// level, from a []byte, returning an error if it is not a state of
+// Ranlux24, e.g. if it is truncated or if one of its fields is out of range
+func (r *Ranlux24) SetStateE(b []byte) error {
+	return r.setState(state.NewDecoder("ranlux24", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (r *Ranlux24) setState(d *state.Decoder) error {
+	g := base24
+	seed, err := decodeState(d, &g)
+	if err != nil {
+		return err
+	}
+	r.seed, r.g = seed, g
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed and the luxury level
+func (r *Ranlux24) Reset() {
+	r.g.seed(r.seed)
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (r *Ranlux24) MarshalBinary() ([]byte, error) { return r.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (r *Ranlux24) UnmarshalBinary(b []byte) error { return r.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (r *Ranlux24) MarshalText() ([]byte, error) {
+	return state.MarshalText(r.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (r *Ranlux24) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("ranlux24", text)
+	if err != nil {
+		return err
+	}
+	return r.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (r *Ranlux24) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("ranlux24", r.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (r *Ranlux24) UnmarshalJSON(data []byte) error {
+	return r.setState(state.NewJSONDecoder("ranlux24", data))
+}
@@ -0,0 +1,218 @@
+package ranlux_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/ranlux"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadir24 = filepath.Join("..", "..", "data", "ranlux24")
+var longTest bool
+
+// TestMain is the entry point for testing
+func TestMain(m *testing.M) {
+	longTest = prngtest.ParseCommandLine()
+	os.Exit(m.Run())
+}
+
+func Test_Ranlux24_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := ranlux.New24(0, 3)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+
+	// Checking that seeds congruent modulo 2147483563 are equivalent
+	r1 := ranlux.New24(5, 3)
+	r2 := ranlux.New24(5+2147483563, 3)
+	assert.Equal(r1.Uint64(), r2.Uint64())
+}
+
+func Test_Ranlux24_Level(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking the 10000th output of each luxury level with the default
+	// seed of the C++ standard library, from
+	// std::discard_block_engine<std::ranlux24_base, p, 23>
+	expected := []uint64{7034066, 6526095, 2032435, 9901578, 15676940}
+	for level, v := range expected {
+		r := ranlux.New24(19780503, level)
+		assert.Equal(level, r.Level())
+		for i := 0; i < 9999; i++ {
+			_ = r.Uint24()
+		}
+		assert.Equal(uint32(v), r.Uint24())
+	}
+
+	for _, level := range []int{-1, 5} {
+		assert.Panics(func() {
+			_ = ranlux.New24(1, level)
+		})
+	}
+}
+
+func Test_Ranlux24_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed remains same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := ranlux.New24(seed, 3)
+		states[i] = r.GetState()
+	}
+	for i, state := range states {
+		r := ranlux.New24(0, 3)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+	}
+
+	// Checking that the streams and the luxury level remain same after
+	// getting and setting states
+	r1 := ranlux.New24(0, 1)
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+	}
+	r2 := ranlux.New24(0, 3)
+	r2.SetState(r1.GetState())
+	assert.Equal(1, r2.Level())
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+		_ = r2.Uint64()
+	}
+	assert.Equal(r1.Uint24(), r2.Uint24())
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	assert.Equal(r1.Float64(), r2.Float64())
+	assert.Equal(r1.Float64OO(), r2.Float64OO())
+	r1.Reset()
+	r2.Reset()
+	assert.Equal(r1.Uint64(), r2.Uint64())
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := ranlux.New24(0, 3)
+		r1.SetState([]byte("Hello"))
+	})
+	assert.Panics(func() {
+		r1 := ranlux.New24(0, 3)
+		r1.SetState(nil)
+	})
+	assert.Panics(func() {
+		r1 := ranlux.New24(0, 3)
+		r1.SetState(ranlux.New48(1, 3).GetState())
+	})
+
+	state := func(level uint64, x [24]uint64, carry, i, n uint64) []byte {
+		buf := new(bytes.Buffer)
+		_ = binary.Write(buf, binary.LittleEndian, []byte("ranlux24"))
+		_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+		_ = binary.Write(buf, binary.LittleEndian, level)
+		_ = binary.Write(buf, binary.LittleEndian, x)
+		_ = binary.Write(buf, binary.LittleEndian, [3]uint64{carry, i, n})
+		return buf.Bytes()
+	}
+	var zeros, ones [24]uint64
+	for i := range ones {
+		ones[i] = 1<<24 - 1
+	}
+	x := [24]uint64{1}
+	assert.NotPanics(func() {
+		r1 := ranlux.New24(0, 3)
+		r1.SetState(state(0, x, 1, 0, 0))
+	})
+	invalid := [][]byte{
+		state(0, x, 1, 0, 0)[:100],
+		state(5, x, 1, 0, 0),
+		state(0, [24]uint64{1 << 24}, 1, 0, 0),
+		state(0, x, 2, 0, 0),
+		state(0, x, 1, 24, 0),
+		state(0, x, 1, 0, 23+1),
+		state(0, zeros, 0, 0, 0),
+		state(0, ones, 1, 0, 0),
+	}
+	for _, b := range invalid {
+		assert.Panics(func() {
+			r1 := ranlux.New24(0, 3)
+			r1.SetState(b)
+		})
+	}
+}
+
+func Test_Ranlux24_Uint64(t *testing.T) {
+	e := ranlux.New24(0, 3)
+	filenames := prngtest.GetDataFiles(datadir24, "ranlux24-*-uint64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_Ranlux24_Float64(t *testing.T) {
+	e := ranlux.New24(0, 3)
+	filenames := prngtest.GetDataFiles(datadir24, "ranlux24-*-float64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_Ranlux24_Float64OO(t *testing.T) {
+	e := ranlux.New24(0, 3)
+	filenames := prngtest.GetDataFiles(datadir24, "ranlux24-*-float64oo-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+// Benchmarks
+func Benchmark_Ranlux24_Uint24(b *testing.B) {
+	rng := ranlux.New24(0, 3)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint24()
+	}
+}
+
+func Benchmark_Ranlux24_Uint64(b *testing.B) {
+	rng := ranlux.New24(0, 3)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_Ranlux24_Float64(b *testing.B) {
+	rng := ranlux.New24(0, 3)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_Ranlux24_Float64OO(b *testing.B) {
+	rng := ranlux.New24(0, 3)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+// Example - Ranlux24 Usage
+func ExampleRanlux24() {
+	// Create a new instance of the Ranlux24 engine at luxury level 3, which is
+	// std::ranlux24
+	r := ranlux.New24(20170612, 3)
+
+	fmt.Println("Ranlux24: seed = 20170612; Uint24()")
+	for i := 0; i < 3; i++ {
+		// Draw 24 random bits
+		fmt.Println(r.Uint24())
+	}
+
+	// Output:
+	// Ranlux24: seed = 20170612; Uint24()
+	// 13062546
+	// 6498779
+	// 11750461
+}
@@ -0,0 +1,177 @@
+package ranlux
+
+import (
+	"encoding/gob"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+)
+
+var (
+	ranlux48 *Ranlux48
+	_        prng.Engine        = ranlux48
+	_        prng.StateRestorer = ranlux48
+)
+
+func init() {
+	prng.Register("ranlux48", func(seed uint64) prng.Engine { return New48(seed, 4) })
+	gob.Register(new(Ranlux48))
+}
+
+// Ranlux48 implements the RANLUX PRNG on 48-bit words, i.e. the
+// subtract-with-carry engine with lags 5 and 12 of std::ranlux48_base, of
+// which 11 values are returned per block of 24, 48, 97, 223 or 389 values
+// depending on the luxury level
+type Ranlux48 struct {
+	seed uint64
+	g    swc
+}
+
+// base48 is the subtract-with-carry engine of Ranlux48, before setting its
+// luxury level and seeding it
+var base48 = swc{w: 48, s: 5, r: 12, used: 11}
+
+// New48 returns a new instance of the Ranlux48 PRNG Engine with the given
+// luxury level, which must be in [0, 4]. At luxury level 4, the engine is
+// std::ranlux48.
+// If the seed provided is 0, the engine is initialized with current time
+func New48(seed uint64, level int) *Ranlux48 {
+	r := &Ranlux48{g: base48}
+	r.g.setLevel(level)
+	r.Seed(seed)
+	return r
+}
+
+// Level returns the luxury level of the engine
+func (r *Ranlux48) Level() int { return r.g.level }
+
+// Uint48 returns a pseudo-random 48-bit value in [0, 2^48) as a uint64,
+// i.e. the output of std::ranlux48 at luxury level 4.
+// Uint48 advances the internal state of the engine.
+func (r *Ranlux48) Uint48() uint64 {
+	return r.g.next()
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64,
+// made of the 48 bits of a value of Uint48 and the 16 most significant bits
+// of the next one.
+// Uint64 advances the internal state of the engine.
+func (r *Ranlux48) Uint64() uint64 {
+	a := r.g.next()
+	return a<<16 | r.g.next()>>32
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (r *Ranlux48) Float64() float64 {
+	return float64(r.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (r *Ranlux48) Float64OO() float64 {
+	return (float64(r.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine as
+// std::ranlux48_base, so that seeds congruent modulo 2147483563 yield the
+// same sequence.
+// If the seed provided is 0, the engine is initialized with current time
+func (r *Ranlux48) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	r.seed = seed
+	r.g.seed(seed)
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (r *Ranlux48) GetSeed() uint64 { return r.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (r *Ranlux48) GetState() []byte {
+	return state.Encode("ranlux48", r.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (r *Ranlux48) fields() []interface{} {
+	return stateFields(r.seed, &r.g)
+}
+
+// SetState sets the internal state of the engine, including its luxury
+// level, from a []byte
+// SetState can be used to resume from a saved state
+func (r *Ranlux48) SetState(b []byte) {
+	if err := r.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine, including its luxury
+// level, from a []byte, returning an error if it is not a state of
+// Ranlux48, e.g. if it is truncated or if one of its fields is out of range
+func (r *Ranlux48) SetStateE(b []byte) error {
+	return r.setState(state.NewDecoder("ranlux48", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (r *Ranlux48) setState(d *state.Decoder) error {
+	g := base48
+	seed, err := decodeState(d, &g)
+	if err != nil {
+		return err
+	}
+	r.seed, r.g = seed, g
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed and the luxury level
+func (r *Ranlux48) Reset() {
+	r.g.seed(r.seed)
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (r *Ranlux48) MarshalBinary() ([]byte, error) { return r.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (r *Ranlux48) UnmarshalBinary(b []byte) error { return r.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (r *Ranlux48) MarshalText() ([]byte, error) {
+	return state.MarshalText(r.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (r *Ranlux48) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("ranlux48", text)
+	if err != nil {
+		return err
+	}
+	return r.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (r *Ranlux48) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("ranlux48", r.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (r *Ranlux48) UnmarshalJSON(data []byte) error {
+	return r.setState(state.NewJSONDecoder("ranlux48", data))
+}
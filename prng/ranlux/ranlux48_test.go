@@ -0,0 +1,210 @@
+package ranlux_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/ranlux"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadir48 = filepath.Join("..", "..", "data", "ranlux48")
+
+func Test_Ranlux48_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := ranlux.New48(0, 4)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+
+	// Checking that seeds congruent modulo 2147483563 are equivalent
+	r1 := ranlux.New48(5, 4)
+	r2 := ranlux.New48(5+2147483563, 4)
+	assert.Equal(r1.Uint64(), r2.Uint64())
+}
+
+func Test_Ranlux48_Level(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking the 10000th output of each luxury level with the default
+	// seed of the C++ standard library, from
+	// std::discard_block_engine<std::ranlux48_base, p, 11>
+	expected := []uint64{244936061593432, 162815099644947, 40605501189306, 96808294730314, 249142670248501}
+	for level, v := range expected {
+		r := ranlux.New48(19780503, level)
+		assert.Equal(level, r.Level())
+		for i := 0; i < 9999; i++ {
+			_ = r.Uint48()
+		}
+		assert.Equal(uint64(v), r.Uint48())
+	}
+
+	for _, level := range []int{-1, 5} {
+		assert.Panics(func() {
+			_ = ranlux.New48(1, level)
+		})
+	}
+}
+
+func Test_Ranlux48_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed remains same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := ranlux.New48(seed, 4)
+		states[i] = r.GetState()
+	}
+	for i, state := range states {
+		r := ranlux.New48(0, 4)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+	}
+
+	// Checking that the streams and the luxury level remain same after
+	// getting and setting states
+	r1 := ranlux.New48(0, 1)
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+	}
+	r2 := ranlux.New48(0, 4)
+	r2.SetState(r1.GetState())
+	assert.Equal(1, r2.Level())
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+		_ = r2.Uint64()
+	}
+	assert.Equal(r1.Uint48(), r2.Uint48())
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	assert.Equal(r1.Float64(), r2.Float64())
+	assert.Equal(r1.Float64OO(), r2.Float64OO())
+	r1.Reset()
+	r2.Reset()
+	assert.Equal(r1.Uint64(), r2.Uint64())
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := ranlux.New48(0, 4)
+		r1.SetState([]byte("Hello"))
+	})
+	assert.Panics(func() {
+		r1 := ranlux.New48(0, 4)
+		r1.SetState(nil)
+	})
+	assert.Panics(func() {
+		r1 := ranlux.New48(0, 4)
+		r1.SetState(ranlux.New24(1, 4).GetState())
+	})
+
+	state := func(level uint64, x [12]uint64, carry, i, n uint64) []byte {
+		buf := new(bytes.Buffer)
+		_ = binary.Write(buf, binary.LittleEndian, []byte("ranlux48"))
+		_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+		_ = binary.Write(buf, binary.LittleEndian, level)
+		_ = binary.Write(buf, binary.LittleEndian, x)
+		_ = binary.Write(buf, binary.LittleEndian, [3]uint64{carry, i, n})
+		return buf.Bytes()
+	}
+	var zeros, ones [12]uint64
+	for i := range ones {
+		ones[i] = 1<<48 - 1
+	}
+	x := [12]uint64{1}
+	assert.NotPanics(func() {
+		r1 := ranlux.New48(0, 4)
+		r1.SetState(state(0, x, 1, 0, 0))
+	})
+	invalid := [][]byte{
+		state(0, x, 1, 0, 0)[:100],
+		state(5, x, 1, 0, 0),
+		state(0, [12]uint64{1 << 48}, 1, 0, 0),
+		state(0, x, 2, 0, 0),
+		state(0, x, 1, 12, 0),
+		state(0, x, 1, 0, 11+1),
+		state(0, zeros, 0, 0, 0),
+		state(0, ones, 1, 0, 0),
+	}
+	for _, b := range invalid {
+		assert.Panics(func() {
+			r1 := ranlux.New48(0, 4)
+			r1.SetState(b)
+		})
+	}
+}
+
+func Test_Ranlux48_Uint64(t *testing.T) {
+	e := ranlux.New48(0, 4)
+	filenames := prngtest.GetDataFiles(datadir48, "ranlux48-*-uint64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_Ranlux48_Float64(t *testing.T) {
+	e := ranlux.New48(0, 4)
+	filenames := prngtest.GetDataFiles(datadir48, "ranlux48-*-float64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_Ranlux48_Float64OO(t *testing.T) {
+	e := ranlux.New48(0, 4)
+	filenames := prngtest.GetDataFiles(datadir48, "ranlux48-*-float64oo-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+// Benchmarks
+func Benchmark_Ranlux48_Uint48(b *testing.B) {
+	rng := ranlux.New48(0, 4)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint48()
+	}
+}
+
+func Benchmark_Ranlux48_Uint64(b *testing.B) {
+	rng := ranlux.New48(0, 4)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_Ranlux48_Float64(b *testing.B) {
+	rng := ranlux.New48(0, 4)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_Ranlux48_Float64OO(b *testing.B) {
+	rng := ranlux.New48(0, 4)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+// Example - Ranlux48 Usage
+func ExampleRanlux48() {
+	// Create a new instance of the Ranlux48 engine at luxury level 4, which is
+	// std::ranlux48
+	r := ranlux.New48(20170612, 4)
+
+	fmt.Println("Ranlux48: seed = 20170612; Uint48()")
+	for i := 0; i < 3; i++ {
+		// Draw 48 random bits
+		fmt.Println(r.Uint48())
+	}
+
+	// Output:
+	// Ranlux48: seed = 20170612; Uint48()
+	// 46024345735570
+	// 161446876105789
+	// 280551414306700
+}
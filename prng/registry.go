@@ -0,0 +1,119 @@
+package prng
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Factory returns a new engine initialized with the seed.
+// If the seed provided is 0, the engine is initialized with current time
+type Factory func(seed uint64) Engine
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register makes an engine available by the name of its algorithm, as
+// written at the start of its states, for New and FromState. Register is
+// called by the init functions of the packages implementing the engines,
+// which must therefore be imported, if only for their side effect.
+// Register panics if it is called twice with the same name or if factory is
+// nil.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if factory == nil {
+		panic("prng: Register factory is nil for " + name)
+	}
+	if _, dup := registry[name]; dup {
+		panic("prng: Register called twice for " + name)
+	}
+	registry[name] = factory
+}
+
+// Algorithms returns the sorted names of the registered engines
+func Algorithms() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New returns a new instance of the engine registered with the name,
+// initialized with the seed.
+// If the seed provided is 0, the engine is initialized with current time
+func New(name string, seed uint64) (Engine, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("prng: Unknown algorithm '%s'", name)
+	}
+	return factory(seed), nil
+}
+
+// FromState returns a new instance of the registered engine whose state is
+// b, as returned by GetState, detecting the engine from the name of the
+// algorithm in b. The states written before version 1 of the Checkpoint
+// format are also accepted.
+func FromState(b []byte) (Engine, error) {
+	if IsCheckpoint(b) {
+		c, err := DecodeCheckpoint(b)
+		if err != nil {
+			return nil, fmt.Errorf("prng: Error decoding state\n%s", err)
+		}
+		return fromState(c.Algorithm, b)
+	}
+
+	// The name of an algorithm may be a prefix of another one, e.g.
+	// "mt19937" of "mt19937ar": the longest name is tried first
+	var names []string
+	for _, name := range Algorithms() {
+		if bytes.HasPrefix(b, []byte(name)) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("prng: Unknown algorithm of state")
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return len(names[i]) > len(names[j])
+	})
+	var err error
+	for _, name := range names {
+		var e Engine
+		if e, err = fromState(name, b); err == nil {
+			return e, nil
+		}
+	}
+	return nil, err
+}
+
+// fromState returns a new instance of the engine registered with the name
+// whose state is b
+func fromState(name string, b []byte) (e Engine, err error) {
+	e, err = New(name, 1)
+	if err != nil {
+		return nil, err
+	}
+	if r, ok := e.(StateRestorer); ok {
+		if err = r.SetStateE(b); err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			e, err = nil, fmt.Errorf("%v", v)
+		}
+	}()
+	e.SetState(b)
+	return e, nil
+}
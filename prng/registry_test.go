@@ -0,0 +1,80 @@
+package prng_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/shivakar/random/prng"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Registry_New(t *testing.T) {
+	assert := assert.New(t)
+
+	engines := restorers(5)
+	var names []string
+	for name := range engines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	assert.Equal(names, prng.Algorithms())
+
+	for name, want := range engines {
+		e, err := prng.New(name, 5)
+		if assert.NoError(err, name) {
+			assert.IsType(want, e, name)
+			assert.Equal(uint64(5), e.GetSeed(), name)
+		}
+	}
+
+	_, err := prng.New("mt19937x", 5)
+	assert.Error(err)
+	_, err = prng.New("", 5)
+	assert.Error(err)
+}
+
+func Test_Registry_Register(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Panics(func() {
+		prng.Register("mt19937", func(seed uint64) prng.Engine { return nil })
+	})
+	assert.Panics(func() {
+		prng.Register("test", nil)
+	})
+}
+
+func Test_Registry_FromState(t *testing.T) {
+	assert := assert.New(t)
+
+	for name, src := range restorers(20170612) {
+		for i := 0; i < 100; i++ {
+			_ = src.Uint64()
+		}
+		c, _ := prng.DecodeCheckpoint(src.GetState())
+		legacy := append([]byte(name), c.Payload...)
+		for _, b := range [][]byte{src.GetState(), legacy} {
+			e, err := prng.FromState(b)
+			if assert.NoError(err, name) {
+				assert.IsType(src, e, name)
+				assert.Equal(src.GetState(), e.GetState(), name)
+			}
+		}
+		e, _ := prng.FromState(src.GetState())
+		assert.Equal(src.Uint64(), e.Uint64(), name)
+	}
+
+	// Invalid states
+	_, err := prng.FromState(nil)
+	assert.Error(err)
+	_, err = prng.FromState([]byte("Hello"))
+	assert.Error(err)
+	b := restorers(1)["mt19937"].GetState()
+	_, err = prng.FromState(b[:len(b)-1])
+	assert.Error(err)
+	_, err = prng.FromState(prng.EncodeCheckpoint("test", nil))
+	assert.Error(err)
+	c, _ := prng.DecodeCheckpoint(b)
+	_, err = prng.FromState(append([]byte("mt19937"), c.Payload[1:]...))
+	assert.Error(err)
+}
@@ -0,0 +1,20 @@
+// Package romu implements two generators of the Romu family of
+// pseudo-random number generators of Mark A. Overton, which combine a
+// multiplication with rotations and have no fixed period:
+//
+//   - RomuTrio has 192 bits of state and is the recommended general-purpose
+//     Romu generator, with an estimated capacity of 2^75 values per stream.
+//   - RomuDuoJr has 128 bits of state and is the fastest Romu generator; it
+//     should be used for jobs drawing at most about 2^51 values.
+//
+// The probability that a seed falls on a short cycle is negligible for
+// these capacities. Romu generators are not suitable for cryptographic use
+// and do not support jump-ahead. A state that is all zero is invalid.
+//
+// References:
+//
+// M. A. Overton, "Romu: Fast Nonlinear Pseudo-Random Number Generators
+// Providing High Quality", arXiv:2002.11331 (2020).
+//
+// https://www.romu-random.org/
+package romu
@@ -0,0 +1,55 @@
+package romu
+
+import (
+	"fmt"
+
+	"github.com/shivakar/random/prng/internal/state"
+)
+
+// multiplier is the multiplier of the Romu generators
+const multiplier uint64 = 15241094284759029579
+
+// stateFields returns the fields of the state of a Romu engine: the seed and
+// the state words
+func stateFields(seed uint64, st []uint64) []interface{} {
+	return []interface{}{
+		uint64(seed),
+		st,
+	}
+}
+
+// decodeState decodes the fields of stateFields by d into st, and returns
+// the seed, or an error if the state is invalid. The state words must not be all zero, since the engine would then
+// only generate zeros.
+func decodeState(d *state.Decoder, st []uint64) (uint64, error) {
+	var seed uint64
+	s := make([]uint64, len(st))
+	d.Read(&seed, s)
+	var or uint64
+	for _, v := range s {
+		or |= v
+	}
+	if or == 0 {
+		d.Fail(fmt.Errorf("State must not be all zero"))
+	}
+	if err := d.Err(); err != nil {
+		return 0, err
+	}
+	copy(st, s)
+	return seed, nil
+}
+
+// seedState sets the state words to consecutive outputs of next, drawing
+// again in the unlikely case that they are all zero
+func seedState(state []uint64, next func() uint64) {
+	for {
+		var or uint64
+		for i := range state {
+			state[i] = next()
+			or |= state[i]
+		}
+		if or != 0 {
+			return
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package romu
+
+import (
+	"encoding/gob"
+	"math/bits"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+var (
+	romuDuoJr *RomuDuoJr
+	_         prng.Engine        = romuDuoJr
+	_         prng.StateRestorer = romuDuoJr
+)
+
+func init() {
+	prng.Register("romuduojr", func(seed uint64) prng.Engine { return NewDuoJr(seed) })
+	gob.Register(new(RomuDuoJr))
+}
+
+// RomuDuoJr implements the RomuDuoJr PRNG with 128 bits of state, the
+// fastest Romu generator, suited to applications drawing fewer than about
+// 2^51 values
+type RomuDuoJr struct {
+	seed  uint64
+	state [2]uint64
+}
+
+// NewDuoJr returns a new instance of the RomuDuoJr PRNG Engine.
+// If the seed provided is 0, the engine is initialized with current time
+func NewDuoJr(seed uint64) *RomuDuoJr {
+	r := new(RomuDuoJr)
+	r.Seed(seed)
+	return r
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64.
+// Uint64 advances the internal state of the engine.
+func (r *RomuDuoJr) Uint64() uint64 {
+	xp := r.state[0]
+	r.state[0] = multiplier * r.state[1]
+	r.state[1] = bits.RotateLeft64(r.state[1]-xp, 27)
+	return xp
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (r *RomuDuoJr) Float64() float64 {
+	return float64(r.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (r *RomuDuoJr) Float64OO() float64 {
+	return (float64(r.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine
+func (r *RomuDuoJr) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	r.seed = seed
+	seedState(r.state[:], splitmix64.New(seed).Uint64)
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (r *RomuDuoJr) GetSeed() uint64 { return r.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (r *RomuDuoJr) GetState() []byte {
+	return state.Encode("romuduojr", r.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (r *RomuDuoJr) fields() []interface{} {
+	return stateFields(r.seed, r.state[:])
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (r *RomuDuoJr) SetState(b []byte) {
+	if err := r.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of RomuDuoJr, e.g. if it is truncated or if its
+// state is all zero
+func (r *RomuDuoJr) SetStateE(b []byte) error {
+	return r.setState(state.NewDecoder("romuduojr", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (r *RomuDuoJr) setState(d *state.Decoder) error {
+	seed, err := decodeState(d, r.state[:])
+	if err != nil {
+		return err
+	}
+	r.seed = seed
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed
+func (r *RomuDuoJr) Reset() {
+	r.Seed(r.seed)
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (r *RomuDuoJr) MarshalBinary() ([]byte, error) { return r.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (r *RomuDuoJr) UnmarshalBinary(b []byte) error { return r.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (r *RomuDuoJr) MarshalText() ([]byte, error) {
+	return state.MarshalText(r.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (r *RomuDuoJr) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("romuduojr", text)
+	if err != nil {
+		return err
+	}
+	return r.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (r *RomuDuoJr) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("romuduojr", r.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (r *RomuDuoJr) UnmarshalJSON(data []byte) error {
+	return r.setState(state.NewJSONDecoder("romuduojr", data))
+}
@@ -0,0 +1,156 @@
+package romu
+
+import (
+	"encoding/gob"
+	"math/bits"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+var (
+	romuTrio *RomuTrio
+	_        prng.Engine        = romuTrio
+	_        prng.StateRestorer = romuTrio
+)
+
+func init() {
+	prng.Register("romutrio", func(seed uint64) prng.Engine { return NewTrio(seed) })
+	gob.Register(new(RomuTrio))
+}
+
+// RomuTrio implements the RomuTrio PRNG with 192 bits of state, the
+// recommended Romu generator for general use
+type RomuTrio struct {
+	seed  uint64
+	state [3]uint64
+}
+
+// NewTrio returns a new instance of the RomuTrio PRNG Engine.
+// If the seed provided is 0, the engine is initialized with current time
+func NewTrio(seed uint64) *RomuTrio {
+	r := new(RomuTrio)
+	r.Seed(seed)
+	return r
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64.
+// Uint64 advances the internal state of the engine.
+func (r *RomuTrio) Uint64() uint64 {
+	xp, yp, zp := r.state[0], r.state[1], r.state[2]
+	r.state[0] = multiplier * zp
+	r.state[1] = bits.RotateLeft64(yp-xp, 12)
+	r.state[2] = bits.RotateLeft64(zp-yp, 44)
+	return xp
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (r *RomuTrio) Float64() float64 {
+	return float64(r.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (r *RomuTrio) Float64OO() float64 {
+	return (float64(r.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine
+func (r *RomuTrio) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	r.seed = seed
+	seedState(r.state[:], splitmix64.New(seed).Uint64)
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (r *RomuTrio) GetSeed() uint64 { return r.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (r *RomuTrio) GetState() []byte {
+	return state.Encode("romutrio", r.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (r *RomuTrio) fields() []interface{} {
+	return stateFields(r.seed, r.state[:])
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (r *RomuTrio) SetState(b []byte) {
+	if err := r.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of RomuTrio, e.g. if it is truncated or if its
+// state is all zero
+func (r *RomuTrio) SetStateE(b []byte) error {
+	return r.setState(state.NewDecoder("romutrio", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (r *RomuTrio) setState(d *state.Decoder) error {
+	seed, err := decodeState(d, r.state[:])
+	if err != nil {
+		return err
+	}
+	r.seed = seed
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed
+func (r *RomuTrio) Reset() {
+	r.Seed(r.seed)
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (r *RomuTrio) MarshalBinary() ([]byte, error) { return r.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (r *RomuTrio) UnmarshalBinary(b []byte) error { return r.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (r *RomuTrio) MarshalText() ([]byte, error) {
+	return state.MarshalText(r.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (r *RomuTrio) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("romutrio", text)
+	if err != nil {
+		return err
+	}
+	return r.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (r *RomuTrio) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("romutrio", r.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (r *RomuTrio) UnmarshalJSON(data []byte) error {
+	return r.setState(state.NewJSONDecoder("romutrio", data))
+}
@@ -0,0 +1,156 @@
+package romu_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/romu"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadirTrio = filepath.Join("..", "..", "data", "romutrio")
+var longTest bool
+
+// TestMain is the entry point for testing
+func TestMain(m *testing.M) {
+	longTest = prngtest.ParseCommandLine()
+	os.Exit(m.Run())
+}
+
+func Test_RomuTrio_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := romu.NewTrio(0)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+}
+
+func Test_RomuTrio_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed remains same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := romu.NewTrio(seed)
+		states[i] = r.GetState()
+	}
+	for i, state := range states {
+		r := romu.NewTrio(0)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+	}
+
+	// Checking that the streams remain same after getting and setting states
+	r1 := romu.NewTrio(0)
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+	}
+	r2 := romu.NewTrio(0)
+	r2.SetState(r1.GetState())
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+		_ = r2.Uint64()
+	}
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	assert.Equal(r1.Float64(), r2.Float64())
+	assert.Equal(r1.Float64OO(), r2.Float64OO())
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := romu.NewTrio(0)
+		r1.SetState([]byte("Hello"))
+	})
+	assert.Panics(func() {
+		r1 := romu.NewTrio(0)
+		r1.SetState(nil)
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("romutrio"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := romu.NewTrio(0)
+		r1.SetState(buf.Bytes())
+	})
+
+	// The state must not be all zero
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("romutrio"))
+	_ = binary.Write(buf, binary.LittleEndian, [4]uint64{10})
+	assert.Panics(func() {
+		r1 := romu.NewTrio(0)
+		r1.SetState(buf.Bytes())
+	})
+}
+
+func Test_RomuTrio_Uint64(t *testing.T) {
+	e := romu.NewTrio(0)
+	filenames := prngtest.GetDataFiles(datadirTrio, "romutrio-*-uint64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_RomuTrio_Float64(t *testing.T) {
+	e := romu.NewTrio(0)
+	filenames := prngtest.GetDataFiles(datadirTrio, "romutrio-*-float64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_RomuTrio_Float64OO(t *testing.T) {
+	e := romu.NewTrio(0)
+	filenames := prngtest.GetDataFiles(datadirTrio, "romutrio-*-float64oo-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+// Benchmarks
+func Benchmark_RomuTrio_Uint64(b *testing.B) {
+	rng := romu.NewTrio(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_RomuTrio_Float64(b *testing.B) {
+	rng := romu.NewTrio(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_RomuTrio_Float64OO(b *testing.B) {
+	rng := romu.NewTrio(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+// Example - RomuTrio Usage
+func ExampleRomuTrio() {
+	// Create a new instance of the RomuTrio engine
+	r := romu.NewTrio(20170612)
+
+	fmt.Println("RomuTrio: seed = 20170612; Uint64()")
+	for i := 0; i < 3; i++ {
+		// Draw 64 random bits as a uint64
+		fmt.Println(r.Uint64())
+	}
+
+	// Output:
+	// RomuTrio: seed = 20170612; Uint64()
+	// 17462441621469506309
+	// 10495757512809619433
+	// 16969626385718948580
+}
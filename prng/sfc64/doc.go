@@ -0,0 +1,16 @@
+// Package sfc64 implements SFC64, the 64-bit Small Fast Chaotic
+// pseudo-random number generator of the PractRand test suite.
+//
+// SFC64 combines a chaotic, non-linear mixing of three 64-bit words with a
+// 64-bit counter, so that every seed has a period of at least 2^64; the
+// average period is about 2^255. It passes PractRand and BigCrush and is
+// one of the fastest generators in this repository. It is not suitable
+// for cryptographic use and, being non-linear, it does not support
+// jump-ahead.
+//
+// References:
+//
+// C. Doty-Humphrey, PractRand, http://pracrand.sourceforge.net/
+//
+// https://numpy.org/doc/stable/reference/random/bit_generators/sfc64.html
+package sfc64
@@ -0,0 +1,177 @@
+package sfc64
+
+import (
+	"encoding/gob"
+	"math/bits"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+var (
+	sfc64 *SFC64
+	_     prng.Engine        = sfc64
+	_     prng.StateRestorer = sfc64
+)
+
+func init() {
+	prng.Register("sfc64", func(seed uint64) prng.Engine { return New(seed) })
+	gob.Register(new(SFC64))
+}
+
+// SFC64 implements the Small Fast Chaotic PRNG of Chris Doty-Humphrey with
+// 256 bits of state, of which 64 bits are a counter guaranteeing a period
+// of at least 2^64
+type SFC64 struct {
+	seed    uint64
+	a, b, c uint64
+	counter uint64
+}
+
+// New returns a new instance of the SFC64 PRNG Engine.
+// If the seed provided is 0, the engine is initialized with current time
+func New(seed uint64) *SFC64 {
+	r := new(SFC64)
+	r.Seed(seed)
+	return r
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64.
+// Uint64 advances the internal state of the engine.
+func (s *SFC64) Uint64() uint64 {
+	tmp := s.a + s.b + s.counter
+	s.counter++
+	s.a = s.b ^ (s.b >> 11)
+	s.b = s.c + (s.c << 3)
+	s.c = bits.RotateLeft64(s.c, 24) + tmp
+	return tmp
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (s *SFC64) Float64() float64 {
+	return float64(s.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (s *SFC64) Float64OO() float64 {
+	return (float64(s.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine. The words a, b
+// and c are consecutive outputs of SplitMix64 seeded with seed and the
+// counter starts at 1; the first 12 outputs are then discarded, as in
+// NumPy's SFC64.
+// If the seed provided is 0, the engine is initialized with current time
+func (s *SFC64) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	s.seed = seed
+	ms := splitmix64.New(seed)
+	s.a = ms.Uint64()
+	s.b = ms.Uint64()
+	s.c = ms.Uint64()
+	s.counter = 1
+	for i := 0; i < 12; i++ {
+		_ = s.Uint64()
+	}
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (s *SFC64) GetSeed() uint64 { return s.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (s *SFC64) GetState() []byte {
+	return state.Encode("sfc64", s.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (s *SFC64) fields() []interface{} {
+	return []interface{}{
+		uint64(s.seed),
+		uint64(s.a),
+		uint64(s.b),
+		uint64(s.c),
+		uint64(s.counter),
+	}
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (s *SFC64) SetState(b []byte) {
+	if err := s.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of SFC64, e.g. if it is truncated
+func (s *SFC64) SetStateE(b []byte) error {
+	return s.setState(state.NewDecoder("sfc64", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (s *SFC64) setState(d *state.Decoder) error {
+	var st [5]uint64
+	d.Read(&st)
+	if err := d.Err(); err != nil {
+		return err
+	}
+	s.seed = st[0]
+	s.a, s.b, s.c, s.counter = st[1], st[2], st[3], st[4]
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed
+func (s *SFC64) Reset() {
+	s.Seed(s.seed)
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (s *SFC64) MarshalBinary() ([]byte, error) { return s.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (s *SFC64) UnmarshalBinary(b []byte) error { return s.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (s *SFC64) MarshalText() ([]byte, error) {
+	return state.MarshalText(s.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (s *SFC64) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("sfc64", text)
+	if err != nil {
+		return err
+	}
+	return s.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (s *SFC64) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("sfc64", s.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (s *SFC64) UnmarshalJSON(data []byte) error {
+	return s.setState(state.NewJSONDecoder("sfc64", data))
+}
@@ -0,0 +1,25 @@
+// Package sfmt implements SFMT19937, the SIMD-oriented Fast Mersenne
+// Twister pseudo-random number generator with period 2^19937-1.
+//
+// SFMT19937 generates the same sequences, through Uint32 and Uint64, as
+// sfmt_genrand_uint32 and sfmt_genrand_uint64 of the reference
+// implementation, for seeds less than 2^32 (sfmt_init_gen_rand) and for keys
+// given to SeedArray (sfmt_init_by_array). As in the reference
+// implementation, Uint64 combines two consecutive 32-bit words of the state,
+// the first one being the least significant. Mixing Uint32 and Uint64 calls
+// is supported, unlike in the reference implementation.
+//
+// FillUint64 fills a slice with the values of Uint64, copying whole blocks
+// of the state, similarly to sfmt_fill_array64.
+//
+// The engine is implemented in portable Go, operating on 128-bit words as
+// pairs of uint64; it does not use SIMD instructions.
+//
+// References:
+//
+// M. Saito and M. Matsumoto, "SIMD-oriented Fast Mersenne Twister: a
+// 128-bit Pseudorandom Number Generator", Monte Carlo and Quasi-Monte Carlo
+// Methods 2006, Springer (2008) 607--622.
+//
+// http://www.math.sci.hiroshima-u.ac.jp/%7Em-mat/MT/SFMT/index.html
+package sfmt
@@ -0,0 +1,433 @@
+package sfmt
+
+import (
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+)
+
+var (
+	sfmt *SFMT19937
+	_    prng.Engine        = sfmt
+	_    prng.StateRestorer = sfmt
+)
+
+func init() {
+	prng.Register("sfmt19937", func(seed uint64) prng.Engine { return New(seed) })
+	gob.Register(new(SFMT19937))
+}
+
+// Parameters of SFMT19937, from SFMT-params19937.h
+const (
+	n    = 156 // number of 128-bit words of the state
+	n32  = n * 4
+	pos1 = 122
+	sl1  = 18
+	sl2  = 1 // in bytes
+	sr1  = 11
+	sr2  = 1 // in bytes
+
+	msk1    uint32 = 0xdfffffef
+	msk2    uint32 = 0xddfecb7f
+	msk3    uint32 = 0xbffaffff
+	msk4    uint32 = 0xbffffff6
+	parity1 uint32 = 0x00000001
+	parity2 uint32 = 0x00000000
+	parity3 uint32 = 0x00000000
+	parity4 uint32 = 0x13c9e684
+)
+
+// Masks of the recursion, for 128-bit words stored as two uint64 values,
+// combining the parameter masks with the masks of the 32-bit shifts
+const (
+	maskSR = (0xFFFFFFFF >> sr1) * 0x100000001
+	maskSL = ((0xFFFFFFFF << sl1) & 0xFFFFFFFF) * 0x100000001
+	maskLo = (uint64(msk2)<<32 | uint64(msk1)) & maskSR
+	maskHi = (uint64(msk4)<<32 | uint64(msk3)) & maskSR
+)
+
+// w128 is a 128-bit word, as its least and most significant 64 bits. The
+// 32-bit words of the reference implementation are, in order, the lower and
+// upper halves of lo and of hi.
+type w128 struct {
+	lo, hi uint64
+}
+
+// SFMT19937 implements the SIMD-oriented Fast Mersenne Twister with period
+// 2^19937-1. The state is regenerated in blocks of 156 128-bit words, i.e.
+// 312 uint64 values.
+type SFMT19937 struct {
+	seed  uint64
+	key   []uint32
+	index int // index of the next 32-bit word in state
+	state [n]w128
+}
+
+// New returns a new instance of the SFMT19937 PRNG Engine.
+// If the seed provided is 0, the engine is initialized with current time
+func New(seed uint64) *SFMT19937 {
+	r := new(SFMT19937)
+	r.Seed(seed)
+	return r
+}
+
+// NewWithArray returns a new instance of the SFMT19937 PRNG Engine
+// initialized with the given key using sfmt_init_by_array
+func NewWithArray(key []uint32) *SFMT19937 {
+	r := new(SFMT19937)
+	r.SeedArray(key)
+	return r
+}
+
+// recursion returns the next 128-bit word computed from a = w[i],
+// b = w[i+pos1], c = w[i-2] and d = w[i-1]
+func recursion(a, b, c, d w128) w128 {
+	// a << 8*sl2 and c >> 8*sr2 as 128-bit integers
+	xlo, xhi := a.lo<<(8*sl2), a.hi<<(8*sl2)|a.lo>>(64-8*sl2)
+	ylo, yhi := c.lo>>(8*sr2)|c.hi<<(64-8*sr2), c.hi>>(8*sr2)
+	return w128{
+		lo: a.lo ^ xlo ^ (b.lo>>sr1)&maskLo ^ ylo ^ (d.lo<<sl1)&maskSL,
+		hi: a.hi ^ xhi ^ (b.hi>>sr1)&maskHi ^ yhi ^ (d.hi<<sl1)&maskSL,
+	}
+}
+
+// generate computes the next block of the state
+func (r *SFMT19937) generate() {
+	s := &r.state
+	r1, r2 := s[n-2], s[n-1]
+	i := 0
+	for ; i < n-pos1; i++ {
+		s[i] = recursion(s[i], s[i+pos1], r1, r2)
+		r1, r2 = r2, s[i]
+	}
+	for ; i < n; i++ {
+		s[i] = recursion(s[i], s[i+pos1-n], r1, r2)
+		r1, r2 = r2, s[i]
+	}
+	r.index = 0
+}
+
+// word returns the i-th 32-bit word of the state
+func (r *SFMT19937) word(i int) uint32 {
+	w := r.state[i/4]
+	if i&2 != 0 {
+		return uint32(w.hi >> (32 * uint(i&1)))
+	}
+	return uint32(w.lo >> (32 * uint(i&1)))
+}
+
+// setWord sets the i-th 32-bit word of the state
+func (r *SFMT19937) setWord(i int, v uint32) {
+	w := &r.state[i/4].lo
+	if i&2 != 0 {
+		w = &r.state[i/4].hi
+	}
+	shift := 32 * uint(i&1)
+	*w = *w&^(0xFFFFFFFF<<shift) | uint64(v)<<shift
+}
+
+// Uint32 returns a pseudo-random 32-bit value in [0, 2^32) as a uint32,
+// i.e. the output of sfmt_genrand_uint32 of the reference implementation.
+// Uint32 advances the internal state of the engine.
+func (r *SFMT19937) Uint32() uint32 {
+	if r.index >= n32 {
+		r.generate()
+	}
+	v := r.word(r.index)
+	r.index++
+	return v
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64,
+// made of two consecutive 32-bit words, the first being the least
+// significant, i.e. the output of sfmt_genrand_uint64 of the reference
+// implementation.
+// Uint64 advances the internal state of the engine.
+func (r *SFMT19937) Uint64() uint64 {
+	if r.index&1 != 0 {
+		lo := uint64(r.Uint32())
+		return lo | uint64(r.Uint32())<<32
+	}
+	if r.index >= n32 {
+		r.generate()
+	}
+	w := &r.state[r.index/4]
+	r.index += 2
+	if r.index&3 == 0 {
+		return w.hi
+	}
+	return w.lo
+}
+
+// FillUint64 fills dst with pseudo-random 64-bit values, i.e. it is
+// equivalent to, but faster than, calling Uint64 len(dst) times. Whole
+// blocks of the state are generated directly into dst.
+func (r *SFMT19937) FillUint64(dst []uint64) {
+	i := 0
+	for ; i < len(dst) && (r.index < n32 || i+2*n > len(dst)); i++ {
+		dst[i] = r.Uint64()
+	}
+	for ; i+2*n <= len(dst); i += 2 * n {
+		r.generate()
+		r.index = n32
+		for k, w := range r.state {
+			dst[i+2*k] = w.lo
+			dst[i+2*k+1] = w.hi
+		}
+	}
+	for ; i < len(dst); i++ {
+		dst[i] = r.Uint64()
+	}
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (r *SFMT19937) Float64() float64 {
+	return float64(r.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (r *SFMT19937) Float64OO() float64 {
+	return (float64(r.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine. Seeds less than
+// 2^32 are used as in sfmt_init_gen_rand. Larger seeds are split into their
+// least and most significant 32 bits and used as key of sfmt_init_by_array.
+// If the seed provided is 0, the engine is initialized with current time
+func (r *SFMT19937) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	r.seed = seed
+	r.key = nil
+	if seed>>32 == 0 {
+		r.initGenRand(uint32(seed))
+		return
+	}
+	r.initByArray([]uint32{uint32(seed), uint32(seed >> 32)})
+}
+
+// SeedArray initializes the engine with the given key as in
+// sfmt_init_by_array. GetSeed returns 0 after SeedArray. SeedArray panics if
+// the key is empty.
+func (r *SFMT19937) SeedArray(key []uint32) {
+	if len(key) == 0 {
+		panic("sfmt: Empty key")
+	}
+	r.seed = 0
+	r.key = append([]uint32(nil), key...)
+	r.initByArray(r.key)
+}
+
+// initGenRand initializes the state using a 32-bit seed
+func (r *SFMT19937) initGenRand(s uint32) {
+	prev := s
+	r.setWord(0, s)
+	for i := 1; i < n32; i++ {
+		prev = 1812433253*(prev^(prev>>30)) + uint32(i)
+		r.setWord(i, prev)
+	}
+	r.index = n32
+	r.certifyPeriod()
+}
+
+// initByArray initializes the state using an array of 32-bit words
+func (r *SFMT19937) initByArray(key []uint32) {
+	const lag = 11
+	const mid = (n32 - lag) / 2
+	func1 := func(x uint32) uint32 { return (x ^ (x >> 27)) * 1664525 }
+	func2 := func(x uint32) uint32 { return (x ^ (x >> 27)) * 1566083941 }
+
+	var s [n32]uint32
+	for i := range s {
+		s[i] = 0x8b8b8b8b
+	}
+	count := n32
+	if len(key)+1 > n32 {
+		count = len(key) + 1
+	}
+	rr := func1(s[0] ^ s[mid] ^ s[n32-1])
+	s[mid] += rr
+	rr += uint32(len(key))
+	s[mid+lag] += rr
+	s[0] = rr
+	count--
+	i, j := 1, 0
+	for ; j < count; j++ {
+		rr = func1(s[i] ^ s[(i+mid)%n32] ^ s[(i+n32-1)%n32])
+		s[(i+mid)%n32] += rr
+		if j < len(key) {
+			rr += key[j]
+		}
+		rr += uint32(i)
+		s[(i+mid+lag)%n32] += rr
+		s[i] = rr
+		i = (i + 1) % n32
+	}
+	for j = 0; j < n32; j++ {
+		rr = func2(s[i] + s[(i+mid)%n32] + s[(i+n32-1)%n32])
+		s[(i+mid)%n32] ^= rr
+		rr -= uint32(i)
+		s[(i+mid+lag)%n32] ^= rr
+		s[i] = rr
+		i = (i + 1) % n32
+	}
+	for k, v := range s {
+		r.setWord(k, v)
+	}
+	r.index = n32
+	r.certifyPeriod()
+}
+
+// certifyPeriod modifies the state, if needed, so that the period of the
+// engine is 2^19937-1
+func (r *SFMT19937) certifyPeriod() {
+	parity := [4]uint32{parity1, parity2, parity3, parity4}
+	var inner uint32
+	for i, p := range parity {
+		inner ^= r.word(i) & p
+	}
+	for i := 16; i > 0; i >>= 1 {
+		inner ^= inner >> uint(i)
+	}
+	if inner&1 == 1 {
+		return
+	}
+	for i, p := range parity {
+		for work := uint32(1); work != 0; work <<= 1 {
+			if work&p != 0 {
+				r.setWord(i, r.word(i)^work)
+				return
+			}
+		}
+	}
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (r *SFMT19937) GetSeed() uint64 { return r.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (r *SFMT19937) GetState() []byte {
+	return state.Encode("sfmt19937", r.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (r *SFMT19937) fields() []interface{} {
+	var st [2 * n]uint64
+	for i, w := range r.state {
+		st[2*i], st[2*i+1] = w.lo, w.hi
+	}
+	return []interface{}{
+		uint64(r.seed),
+		uint64(len(r.key)),
+		r.key,
+		uint64(r.index),
+		st,
+	}
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (r *SFMT19937) SetState(b []byte) {
+	if err := r.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of SFMT19937, e.g. if it is truncated, if its
+// index is not in [0, 624] or if its state is all zero
+func (r *SFMT19937) SetStateE(b []byte) error {
+	return r.setState(state.NewDecoder("sfmt19937", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (r *SFMT19937) setState(d *state.Decoder) error {
+	var seed, keyLen, index uint64
+	var key []uint32
+	var st [2 * n]uint64
+	d.Read(&seed, &keyLen)
+	if keyLen > uint64(d.Len())/4 {
+		d.Fail(fmt.Errorf("Key length %d exceeds the data", keyLen))
+	} else if keyLen > 0 {
+		key = make([]uint32, keyLen)
+	} else if seed == 0 {
+		d.Fail(fmt.Errorf("Expected a seed or a key"))
+	}
+	d.Read(key, &index, &st)
+	if index > uint64(n32) {
+		d.Fail(fmt.Errorf("Invalid index %d", index))
+	}
+	if st == [2 * n]uint64{} {
+		d.Fail(fmt.Errorf("State must not be all zero"))
+	}
+	if err := d.Err(); err != nil {
+		return err
+	}
+	r.seed = seed
+	r.key = key
+	r.index = int(index)
+	for i := range r.state {
+		r.state[i] = w128{lo: st[2*i], hi: st[2*i+1]}
+	}
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed, or the key if the engine was initialized by SeedArray
+func (r *SFMT19937) Reset() {
+	if r.key != nil {
+		r.initByArray(r.key)
+		return
+	}
+	r.Seed(r.seed)
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (r *SFMT19937) MarshalBinary() ([]byte, error) { return r.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (r *SFMT19937) UnmarshalBinary(b []byte) error { return r.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (r *SFMT19937) MarshalText() ([]byte, error) {
+	return state.MarshalText(r.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (r *SFMT19937) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("sfmt19937", text)
+	if err != nil {
+		return err
+	}
+	return r.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (r *SFMT19937) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("sfmt19937", r.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (r *SFMT19937) UnmarshalJSON(data []byte) error {
+	return r.setState(state.NewJSONDecoder("sfmt19937", data))
+}
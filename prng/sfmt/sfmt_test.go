@@ -0,0 +1,277 @@
+package sfmt_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/mt19937"
+	"github.com/shivakar/random/prng/sfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadir = filepath.Join("..", "..", "data", "sfmt")
+var longTest bool
+
+// TestMain is the entry point for testing
+func TestMain(m *testing.M) {
+	longTest = prngtest.ParseCommandLine()
+	os.Exit(m.Run())
+}
+
+func Test_SFMT19937_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+	seeds := []uint64{1, 5, 10, 1024, 200000, 6662751973}
+	r := sfmt.New(0)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+	r.SeedArray([]uint32{1, 2})
+	assert.Zero(r.GetSeed())
+
+	assert.Panics(func() {
+		r.SeedArray(nil)
+	})
+}
+
+func Test_SFMT19937_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed remains same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024, 6662751973}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := sfmt.New(seed)
+		states[i] = r.GetState()
+	}
+
+	for i, state := range states {
+		r := sfmt.New(0)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+	}
+
+	// Checking that the streams remain same after
+	// getting and setting states
+	r1 := sfmt.New(0)
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint32()
+	}
+	r2 := sfmt.New(0)
+	r2.SetState(r1.GetState())
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+		_ = r2.Uint64()
+	}
+	assert.Equal(r1.Uint32(), r2.Uint32())
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	assert.Equal(r1.Float64(), r2.Float64())
+	assert.Equal(r1.Float64OO(), r2.Float64OO())
+
+	// Checking that the key of SeedArray is restored, so that Reset
+	// reverts to the same stream
+	r1 = sfmt.NewWithArray([]uint32{0x1234, 0x5678, 0x9abc, 0xdef0})
+	v := r1.Uint32()
+	r2 = sfmt.New(0)
+	r2.SetState(r1.GetState())
+	r2.Reset()
+	assert.Equal(v, r2.Uint32())
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := sfmt.New(0)
+		r1.SetState([]byte("Hello"))
+	})
+	assert.Panics(func() {
+		r1 := sfmt.New(0)
+		r1.SetState(nil)
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("sfmt19937"))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := sfmt.New(0)
+		r1.SetState(buf.Bytes())
+	})
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("sfmt19937"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(1000))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := sfmt.New(0)
+		r1.SetState(buf.Bytes())
+	})
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("sfmt19937"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(0))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := sfmt.New(0)
+		r1.SetState(buf.Bytes())
+	})
+
+	// Index beyond the end of the state, and neither seed nor key
+	for _, v := range [][2]uint64{{10, 625}, {0, 0}} {
+		buf = new(bytes.Buffer)
+		_ = binary.Write(buf, binary.LittleEndian, []byte("sfmt19937"))
+		_ = binary.Write(buf, binary.LittleEndian, v[0])
+		_ = binary.Write(buf, binary.LittleEndian, uint64(0))
+		_ = binary.Write(buf, binary.LittleEndian, v[1])
+		_ = binary.Write(buf, binary.LittleEndian, [624]uint32{})
+		assert.Panics(func() {
+			r1 := sfmt.New(0)
+			r1.SetState(buf.Bytes())
+		})
+	}
+}
+
+func Test_SFMT19937_Uint32(t *testing.T) {
+	e := sfmt.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "sfmt19937-*-uint32-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_SFMT19937_Uint64(t *testing.T) {
+	e := sfmt.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "sfmt19937-*-uint64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_SFMT19937_Float64(t *testing.T) {
+	e := sfmt.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "sfmt19937-*-float64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_SFMT19937_Float64OO(t *testing.T) {
+	e := sfmt.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "sfmt19937-*-float64oo-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_SFMT19937_Reference(t *testing.T) {
+	assert := assert.New(t)
+
+	// First outputs of sfmt_genrand_uint32 in SFMT.19937.out.txt of the
+	// reference implementation, for both initializations
+	expected := []uint32{3440181298, 1564997079, 1510669302, 2930277156,
+		1452439940, 3796268453, 423124208, 2143818589, 3827219408,
+		2987036003}
+	r := sfmt.New(1234)
+	for _, v := range expected {
+		assert.Equal(v, r.Uint32())
+	}
+	expected = []uint32{2920711183, 3885745737, 3501893680, 856470934,
+		1421864068, 277361036, 1518638004, 2328404353, 3355513634,
+		64329189}
+	r = sfmt.NewWithArray([]uint32{0x1234, 0x5678, 0x9abc, 0xdef0})
+	for _, v := range expected {
+		assert.Equal(v, r.Uint32())
+	}
+
+	// First outputs of sfmt_genrand_uint64 in SFMT.19937.out.txt
+	expected64 := []uint64{16924766246869039260, 8201438687333352714,
+		2265290287015001750, 18397264611805473832, 3375255223302384358}
+	r = sfmt.New(4321)
+	for _, v := range expected64 {
+		assert.Equal(v, r.Uint64())
+	}
+}
+
+func Test_SFMT19937_FillUint64(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking that FillUint64 matches Uint64 for various lengths and
+	// positions in the state, including odd numbers of 32-bit words drawn
+	lengths := []int{0, 1, 311, 312, 313, 1000, 5000}
+	for _, skip := range []int{0, 1, 2, 623, 624} {
+		for _, l := range lengths {
+			r1 := sfmt.New(20170612)
+			r2 := sfmt.New(20170612)
+			for i := 0; i < skip; i++ {
+				_ = r1.Uint32()
+				_ = r2.Uint32()
+			}
+			dst := make([]uint64, l)
+			r1.FillUint64(dst)
+			for _, v := range dst {
+				assert.Equal(r2.Uint64(), v)
+			}
+			assert.Equal(r2.Uint32(), r1.Uint32())
+		}
+	}
+}
+
+// Benchmarks
+func Benchmark_SFMT19937_Uint32(b *testing.B) {
+	rng := sfmt.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint32()
+	}
+}
+
+func Benchmark_SFMT19937_Uint64(b *testing.B) {
+	rng := sfmt.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_SFMT19937_FillUint64(b *testing.B) {
+	rng := sfmt.New(0)
+	dst := make([]uint64, 1<<16)
+	b.SetBytes(8 * int64(len(dst)))
+	for i := 0; i < b.N; i++ {
+		rng.FillUint64(dst)
+	}
+}
+
+func Benchmark_MT19937_FillUint64(b *testing.B) {
+	rng := mt19937.New(0)
+	dst := make([]uint64, 1<<16)
+	b.SetBytes(8 * int64(len(dst)))
+	for i := 0; i < b.N; i++ {
+		for j := range dst {
+			dst[j] = rng.Uint64()
+		}
+	}
+}
+
+func Benchmark_SFMT19937_Float64(b *testing.B) {
+	rng := sfmt.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_SFMT19937_Float64OO(b *testing.B) {
+	rng := sfmt.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+// Example - Bulk generation with SFMT19937
+func ExampleSFMT19937_FillUint64() {
+	r := sfmt.New(4321)
+
+	// Fill a slice with 64-bit values, as sfmt_fill_array64 does
+	values := make([]uint64, 1000)
+	r.FillUint64(values)
+	fmt.Println(values[0], values[1])
+
+	// Output:
+	// 16924766246869039260 8201438687333352714
+}
@@ -0,0 +1,23 @@
+// Package siphash implements a PRNG based on the SipHash-2-4 pseudo-random
+// function of Aumasson and Bernstein, used in counter mode.
+//
+// The n-th value of the engine is SipHash-2-4 of the counter n, encoded as
+// 8 little-endian bytes, with a secret 128-bit key. As SipHash is designed
+// to be indistinguishable from a random function by anyone not knowing the
+// key, the sequence is unpredictable, while being fully determined by the
+// key. Every value is computed independently, so Seek moves the engine to
+// any counter and Advance (prng.Advancer) skips any number of values in
+// constant time. The period is 2^64.
+//
+// Seed derives the key from a 64-bit seed using SplitMix64, which is
+// convenient for reproducible tests but provides at most 64 bits of
+// security; use NewWithKey or SetKey with a secret 128-bit key where
+// unpredictability matters.
+//
+// References:
+//
+// J.-P. Aumasson and D. J. Bernstein, "SipHash: a fast short-input PRF",
+// INDOCRYPT 2012, LNCS 7668 (2012) 489--508.
+//
+// https://github.com/veorq/SipHash
+package siphash
@@ -0,0 +1,243 @@
+package siphash
+
+import (
+	"encoding/binary"
+	"encoding/gob"
+	"math/bits"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+var (
+	siphash *SipHash
+	_       prng.Engine        = siphash
+	_       prng.Advancer      = siphash
+	_       prng.StateRestorer = siphash
+)
+
+func init() {
+	prng.Register("siphash", func(seed uint64) prng.Engine { return New(seed) })
+	gob.Register(new(SipHash))
+}
+
+// SipHash implements a PRNG whose n-th value is the SipHash-2-4 keyed hash
+// of the counter n, encoded as 8 little-endian bytes, with a 128-bit key.
+// The period is 2^64.
+type SipHash struct {
+	seed    uint64
+	k0, k1  uint64
+	counter uint64
+}
+
+// New returns a new instance of the SipHash PRNG Engine. The key is derived
+// from the seed using SplitMix64.
+// If the seed provided is 0, the engine is initialized with current time
+func New(seed uint64) *SipHash {
+	r := new(SipHash)
+	r.Seed(seed)
+	return r
+}
+
+// NewWithKey returns a new instance of the SipHash PRNG Engine using the
+// given 128-bit key. GetSeed returns 0 for engines created by NewWithKey.
+func NewWithKey(key [16]byte) *SipHash {
+	r := new(SipHash)
+	r.SetKey(key)
+	return r
+}
+
+// round is the SipRound function applied to the internal state v
+func round(v0, v1, v2, v3 uint64) (uint64, uint64, uint64, uint64) {
+	v0 += v1
+	v1 = bits.RotateLeft64(v1, 13)
+	v1 ^= v0
+	v0 = bits.RotateLeft64(v0, 32)
+	v2 += v3
+	v3 = bits.RotateLeft64(v3, 16)
+	v3 ^= v2
+	v0 += v3
+	v3 = bits.RotateLeft64(v3, 21)
+	v3 ^= v0
+	v2 += v1
+	v1 = bits.RotateLeft64(v1, 17)
+	v1 ^= v2
+	v2 = bits.RotateLeft64(v2, 32)
+	return v0, v1, v2, v3
+}
+
+// hash returns SipHash-2-4 of the 8-byte little-endian encoding of m with
+// the key k0, k1, i.e. with two compression rounds for the message word and
+// for the final word holding the message length, and four finalization
+// rounds
+func hash(k0, k1, m uint64) uint64 {
+	v0 := k0 ^ 0x736F6D6570736575
+	v1 := k1 ^ 0x646F72616E646F6D
+	v2 := k0 ^ 0x6C7967656E657261
+	v3 := k1 ^ 0x7465646279746573
+	for _, w := range []uint64{m, 8 << 56} {
+		v3 ^= w
+		v0, v1, v2, v3 = round(v0, v1, v2, v3)
+		v0, v1, v2, v3 = round(v0, v1, v2, v3)
+		v0 ^= w
+	}
+	v2 ^= 0xFF
+	for i := 0; i < 4; i++ {
+		v0, v1, v2, v3 = round(v0, v1, v2, v3)
+	}
+	return v0 ^ v1 ^ v2 ^ v3
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64.
+// Uint64 advances the internal state of the engine.
+func (s *SipHash) Uint64() uint64 {
+	v := hash(s.k0, s.k1, s.counter)
+	s.counter++
+	return v
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (s *SipHash) Float64() float64 {
+	return float64(s.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (s *SipHash) Float64OO() float64 {
+	return (float64(s.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine. The two halves of
+// the key are consecutive outputs of SplitMix64 seeded with seed.
+// If the seed provided is 0, the engine is initialized with current time
+func (s *SipHash) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	s.seed = seed
+	ms := splitmix64.New(seed)
+	s.k0 = ms.Uint64()
+	s.k1 = ms.Uint64()
+	s.counter = 0
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (s *SipHash) GetSeed() uint64 { return s.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (s *SipHash) GetState() []byte {
+	return state.Encode("siphash", s.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (s *SipHash) fields() []interface{} {
+	return []interface{}{
+		uint64(s.seed),
+		uint64(s.k0),
+		uint64(s.k1),
+		uint64(s.counter),
+	}
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (s *SipHash) SetState(b []byte) {
+	if err := s.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of SipHash, e.g. if it is truncated
+func (s *SipHash) SetStateE(b []byte) error {
+	return s.setState(state.NewDecoder("siphash", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (s *SipHash) setState(d *state.Decoder) error {
+	var seed, k0, k1, counter uint64
+	d.Read(&seed, &k0, &k1, &counter)
+	if err := d.Err(); err != nil {
+		return err
+	}
+	s.seed = seed
+	s.k0 = k0
+	s.k1 = k1
+	s.counter = counter
+	return nil
+}
+
+// Reset reverts the internal state of the engine to the start of its
+// sequence, keeping the seed and key
+func (s *SipHash) Reset() {
+	s.counter = 0
+}
+
+// Advance advances the internal state of the engine by n steps, i.e. it is
+// equivalent to calling Uint64 n times, in constant time
+func (s *SipHash) Advance(n uint64) {
+	s.counter += n
+}
+
+// Seek moves the engine to the counter c, i.e. the next call to Uint64
+// returns the hash of c, which is the c-th value of the sequence
+func (s *SipHash) Seek(c uint64) {
+	s.counter = c
+}
+
+// SetKey sets the 128-bit key of the engine, read as two little-endian
+// 64-bit words as in the reference implementation, and moves the engine to
+// the start of its sequence. The seed is left unchanged: Reset keeps the
+// key, whereas Seed replaces it with the key derived from the new seed.
+func (s *SipHash) SetKey(key [16]byte) {
+	s.k0 = binary.LittleEndian.Uint64(key[:8])
+	s.k1 = binary.LittleEndian.Uint64(key[8:])
+	s.counter = 0
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (s *SipHash) MarshalBinary() ([]byte, error) { return s.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (s *SipHash) UnmarshalBinary(b []byte) error { return s.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (s *SipHash) MarshalText() ([]byte, error) {
+	return state.MarshalText(s.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (s *SipHash) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("siphash", text)
+	if err != nil {
+		return err
+	}
+	return s.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (s *SipHash) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("siphash", s.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (s *SipHash) UnmarshalJSON(data []byte) error {
+	return s.setState(state.NewJSONDecoder("siphash", data))
+}
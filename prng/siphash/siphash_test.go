@@ -0,0 +1,201 @@
+package siphash_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/siphash"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadir = filepath.Join("..", "..", "data", "siphash")
+var longTest bool
+
+// TestMain is the entry point for testing
+func TestMain(m *testing.M) {
+	longTest = prngtest.ParseCommandLine()
+	os.Exit(m.Run())
+}
+
+func Test_SipHash_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := siphash.New(0)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+}
+
+func Test_SipHash_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed remains same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := siphash.New(seed)
+		states[i] = r.GetState()
+	}
+	for i, state := range states {
+		r := siphash.New(0)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+	}
+
+	// Checking that the streams remain same after getting and setting states
+	r1 := siphash.New(0)
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+	}
+	r2 := siphash.New(0)
+	r2.SetState(r1.GetState())
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+		_ = r2.Uint64()
+	}
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	assert.Equal(r1.Float64(), r2.Float64())
+	assert.Equal(r1.Float64OO(), r2.Float64OO())
+	r1.Reset()
+	r2.Reset()
+	assert.Equal(r1.Uint64(), r2.Uint64())
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := siphash.New(0)
+		r1.SetState([]byte("Hello"))
+	})
+	assert.Panics(func() {
+		r1 := siphash.New(0)
+		r1.SetState(nil)
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("siphash"))
+	_ = binary.Write(buf, binary.LittleEndian, [3]uint64{10, 1, 2})
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := siphash.New(0)
+		r1.SetState(buf.Bytes())
+	})
+}
+
+func Test_SipHash_Uint64(t *testing.T) {
+	e := siphash.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "siphash-*-uint64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_SipHash_Float64(t *testing.T) {
+	e := siphash.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "siphash-*-float64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_SipHash_Float64OO(t *testing.T) {
+	e := siphash.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "siphash-*-float64oo-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_SipHash_Reference(t *testing.T) {
+	assert := assert.New(t)
+
+	// SipHash-2-4 of the 8-byte message 00 01 ... 07, i.e. of the counter
+	// 0x0706050403020100, with the key 00 01 ... 0f, from the test vectors
+	// of the reference implementation
+	var key [16]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	r := siphash.NewWithKey(key)
+	assert.Zero(r.GetSeed())
+	r.Seek(0x0706050403020100)
+	assert.Equal(uint64(0x93f5f5799a932462), r.Uint64())
+}
+
+func Test_SipHash_AdvanceSeek(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking that Advance matches drawing from the engine
+	r1 := siphash.New(20170612)
+	r2 := siphash.New(20170612)
+	for _, n := range []uint64{0, 1, 2, 3, 100, 1000} {
+		for i := uint64(0); i < n; i++ {
+			_ = r1.Uint64()
+		}
+		r2.Advance(n)
+		assert.Equal(r1.GetState(), r2.GetState())
+	}
+
+	// Checking that Seek(c) is equivalent to Advance(c) from the start
+	r1.Seek(123456789)
+	r2.Reset()
+	r2.Advance(123456789)
+	assert.Equal(r2.Uint64(), r1.Uint64())
+
+	// Checking that Reset keeps a key set by SetKey
+	var key [16]byte
+	for i := range key {
+		key[i] = byte(3 * i)
+	}
+	r1 = siphash.NewWithKey(key)
+	v := r1.Uint64()
+	r1.Seed(1)
+	assert.Equal(siphash.New(1).Uint64(), r1.Uint64())
+	r1.SetKey(key)
+	assert.Equal(v, r1.Uint64())
+	r1.Reset()
+	assert.Equal(uint64(1), r1.GetSeed())
+	assert.Equal(v, r1.Uint64())
+}
+
+// Benchmarks
+func Benchmark_SipHash_Uint64(b *testing.B) {
+	rng := siphash.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_SipHash_Float64(b *testing.B) {
+	rng := siphash.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_SipHash_Float64OO(b *testing.B) {
+	rng := siphash.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+// Example - SipHash Usage
+func ExampleSipHash() {
+	// Create a new instance of the SipHash engine
+	r := siphash.New(20170612)
+
+	fmt.Println("SipHash: seed = 20170612; Uint64()")
+	for i := 0; i < 3; i++ {
+		// Draw 64 random bits as a uint64
+		fmt.Println(r.Uint64())
+	}
+
+	// Output:
+	// SipHash: seed = 20170612; Uint64()
+	// 14131869046719919240
+	// 8554400083452593202
+	// 13346274611087147673
+}
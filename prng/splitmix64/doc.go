@@ -2,7 +2,19 @@
 //
 // SplitMix64 is a very fast generator passing BigCrush with 64-bits of state.
 //
+// The state of the engine after n draws is seed + n*gamma, hence SplitMix64
+// can move to any position in its stream in constant time using Advance.
+//
+// SplitMix64 implements prng.Splitter using the algorithm of Java's
+// SplittableRandom: engines returned by Split use a different increment
+// (gamma) for their Weyl sequence, so that the tree of engines obtained by
+// splitting a seeded engine is reproducible. An engine seeded with Seed
+// generates the same stream as SplittableRandom constructed with that seed.
+//
 // References:
 //
 // http://xoroshiro.di.unimi.it/splitmix64.c - Written by Sebastiano Vigna
+//
+// G. L. Steele Jr., D. Lea and C. H. Flood, "Fast Splittable Pseudorandom
+// Number Generators", OOPSLA 2014.
 package splitmix64
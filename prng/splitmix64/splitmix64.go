@@ -1,25 +1,68 @@
 package splitmix64
 
 import (
-	"bytes"
-	"encoding/binary"
+	"encoding/gob"
 	"fmt"
-	"strings"
+	"math/bits"
 	"time"
 
 	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
 )
 
 var (
 	splitmix64 *SplitMix64
-	_          prng.Engine = splitmix64
+	_          prng.Engine        = splitmix64
+	_          prng.Advancer      = splitmix64
+	_          prng.Splitter      = splitmix64
+	_          prng.StateRestorer = splitmix64
 )
 
+func init() {
+	prng.Register("splitmix64", func(seed uint64) prng.Engine { return New(seed) })
+	gob.Register(new(SplitMix64))
+}
+
+// goldenGamma is the default increment of the Weyl sequence, i.e. the odd
+// integer closest to 2^64/phi, where phi is the golden ratio
+const goldenGamma uint64 = 0x9E3779B97F4A7C15
+
+// mix64 is the avalanching function applied to the state of the engine
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * uint64(0xBF58476D1CE4E5B9)
+	z = (z ^ (z >> 27)) * uint64(0x94D049BB133111EB)
+	return z ^ (z >> 31)
+}
+
+// mixGamma returns an odd increment for the Weyl sequence of a new engine,
+// avoiding increments with too few 01 or 10 bit transitions
+func mixGamma(z uint64) uint64 {
+	z = (z ^ (z >> 33)) * uint64(0xFF51AFD7ED558CCD)
+	z = (z ^ (z >> 33)) * uint64(0xC4CEB9FE1A85EC53)
+	z = (z ^ (z >> 33)) | 1
+	if bits.OnesCount64(z^(z>>1)) < 24 {
+		z ^= 0xAAAAAAAAAAAAAAAA
+	}
+	return z
+}
+
+// inverse returns the multiplicative inverse of an odd x modulo 2^64
+func inverse(x uint64) uint64 {
+	// x*x = 1 mod 8 for odd x. Each Newton iteration doubles the number of
+	// correct bits.
+	y := x
+	for i := 0; i < 5; i++ {
+		y *= 2 - x*y
+	}
+	return y
+}
+
 // SplitMix64 implements the avalanching function based PRNG
 // by Sebastiano Vigna
 type SplitMix64 struct {
 	seed  uint64
 	state uint64
+	gamma uint64
 }
 
 // New returns a new instance of the SplitMix64 PRNG Engine.
@@ -37,11 +80,8 @@ func New(seed uint64) *SplitMix64 {
 // Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64.
 // Uint64 advances the internal state of the engine.
 func (s *SplitMix64) Uint64() uint64 {
-	s.state += uint64(0x9E3779B97F4A7C15)
-	z := s.state
-	z = (z ^ (z >> 30)) * uint64(0xBF58476D1CE4E5B9)
-	z = (z ^ (z >> 27)) * uint64(0x94D049BB133111EB)
-	return z ^ (z >> 31)
+	s.state += s.gamma
+	return mix64(s.state)
 }
 
 // Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
@@ -63,6 +103,7 @@ func (s *SplitMix64) Seed(seed uint64) {
 	}
 	s.seed = seed
 	s.state = seed
+	s.gamma = goldenGamma
 }
 
 // GetSeed returns the seed used to initialize the engine
@@ -71,45 +112,119 @@ func (s *SplitMix64) GetSeed() uint64 { return s.seed }
 // GetState returns the internal state of the engine as []byte
 // GetState can be used to save the state, e.g. to a file
 func (s *SplitMix64) GetState() []byte {
-	const msg = "splitmix64: Error encoding state"
-	buf := new(bytes.Buffer)
-	data := []interface{}{
-		[]byte("splitmix64"),
+	return state.Encode("splitmix64", s.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (s *SplitMix64) fields() []interface{} {
+	return []interface{}{
 		uint64(s.seed),
 		uint64(s.state),
+		uint64(s.gamma),
 	}
-	for _, v := range data {
-		if err := binary.Write(buf, binary.LittleEndian, v); err != nil {
-			panic(strings.Join([]string{msg, err.Error()}, "\n"))
-		}
-	}
-	return buf.Bytes()
 }
 
 // SetState sets the internal state of the engine from a []byte
 // SetState can be used to resume from a saved state
 func (s *SplitMix64) SetState(b []byte) {
-	const msg = "splitmix64: Error decoding state"
-	buf := bytes.NewReader(b)
-	nb := make([]byte, 10)
-	_, err := buf.Read(nb)
-	if err != nil {
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+	if err := s.SetStateE(b); err != nil {
+		panic(err.Error())
 	}
-	if string(nb) != "splitmix64" {
-		err = fmt.Errorf("Expected 'splitmix64', got '%s'", string(nb))
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of SplitMix64, e.g. if it is truncated or if
+// its increment is even
+func (s *SplitMix64) SetStateE(b []byte) error {
+	return s.setState(state.NewDecoder("splitmix64", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (s *SplitMix64) setState(d *state.Decoder) error {
+	var seed, st uint64
+	d.Read(&seed, &st)
+	// States saved before the increment was part of the state use the
+	// default increment
+	gamma := goldenGamma
+	if d.Len() > 0 {
+		d.Read(&gamma)
 	}
-	if err = binary.Read(buf, binary.LittleEndian, &s.seed); err != nil {
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+	if gamma&1 == 0 {
+		d.Fail(fmt.Errorf("Increment %#x must be odd", gamma))
 	}
-	if err = binary.Read(buf, binary.LittleEndian, &s.state); err != nil {
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+	if err := d.Err(); err != nil {
+		return err
 	}
+	s.seed = seed
+	s.state = st
+	s.gamma = gamma
+	return nil
+}
+
+// Advance advances the internal state of the engine by n steps, i.e. it is
+// equivalent to calling Uint64 n times. Since the state of the engine is a
+// Weyl sequence, Advance takes constant time.
+func (s *SplitMix64) Advance(n uint64) {
+	s.state += n * s.gamma
+}
+
+// Position returns the number of steps the engine has advanced since it was
+// seeded, modulo 2^64, i.e. the index of the next draw in the stream
+func (s *SplitMix64) Position() uint64 {
+	return (s.state - s.seed) * inverse(s.gamma)
+}
+
+// Split returns a new SplitMix64 engine as in Java's SplittableRandom: its
+// state is the next output of this engine and the increment of its Weyl
+// sequence is derived from the following state of this engine.
+// Split advances the internal state of the engine by two steps.
+func (s *SplitMix64) Split() prng.Engine {
+	seed := s.Uint64()
+	s.state += s.gamma
+	return &SplitMix64{seed: seed, state: seed, gamma: mixGamma(s.state)}
 }
 
 // Reset reverts the internal state of the engine to its default state,
 // except the seed
 func (s *SplitMix64) Reset() {
-	s.Seed(s.seed)
+	s.state = s.seed
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (s *SplitMix64) MarshalBinary() ([]byte, error) { return s.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (s *SplitMix64) UnmarshalBinary(b []byte) error { return s.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (s *SplitMix64) MarshalText() ([]byte, error) {
+	return state.MarshalText(s.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (s *SplitMix64) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("splitmix64", text)
+	if err != nil {
+		return err
+	}
+	return s.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (s *SplitMix64) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("splitmix64", s.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (s *SplitMix64) UnmarshalJSON(data []byte) error {
+	return s.setState(state.NewJSONDecoder("splitmix64", data))
 }
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/shivakar/random/prng"
 	"github.com/shivakar/random/prng/internal/prngtest"
 	"github.com/shivakar/random/prng/splitmix64"
 	"github.com/stretchr/testify/assert"
@@ -109,6 +110,82 @@ func Test_SplitMix64_Float64OO(t *testing.T) {
 	prngtest.CompareDraws(t, e, filenames, longTest)
 }
 
+func Test_SplitMix64_AdvancePosition(t *testing.T) {
+	assert := assert.New(t)
+
+	r1 := splitmix64.New(20170612)
+	r2 := splitmix64.New(20170612)
+	assert.Equal(uint64(0), r1.Position())
+
+	// Checking that Advance matches drawing from the engine
+	steps := []uint64{0, 1, 17, 1024, 674637}
+	pos := uint64(0)
+	for _, n := range steps {
+		r1.Advance(n)
+		for i := uint64(0); i < n; i++ {
+			_ = r2.Uint64()
+		}
+		pos += n
+		assert.Equal(pos, r1.Position())
+		assert.Equal(pos, r2.Position())
+		assert.Equal(r2.Uint64(), r1.Uint64())
+		pos++
+	}
+
+	// Checking indexing directly into the stream
+	r1.Reset()
+	r1.Advance(5000000000)
+	assert.Equal(uint64(5000000000), r1.Position())
+	r1.Advance(^uint64(0))
+	assert.Equal(uint64(4999999999), r1.Position())
+}
+
+func Test_SplitMix64_Split(t *testing.T) {
+	assert := assert.New(t)
+	prngtest.CheckSplit(t, splitmix64.New(20170612))
+
+	// Expected values from Java's SplittableRandom(20170612L)
+	r := splitmix64.New(20170612)
+	c := r.Split()
+	assert.Equal(uint64(17462441621469506309), c.GetSeed())
+	for _, v := range []uint64{2347054819662066992, 11800093427018832258,
+		16509829892624861328, 5096561930466680017, 5586106402394303488} {
+		assert.Equal(v, c.Uint64())
+	}
+	for _, v := range []uint64{10663058410636140827, 14336317500017728102,
+		15977207468038661460} {
+		assert.Equal(v, r.Uint64())
+	}
+	g := c.(prng.Splitter).Split()
+	for _, v := range []uint64{12706949124797538875, 3193219947249788447,
+		2863409589384357831} {
+		assert.Equal(v, g.Uint64())
+	}
+
+	// Checking that Reset, Advance and Position use the increment of the
+	// split engine
+	sc := c.(*splitmix64.SplitMix64)
+	sc.Reset()
+	assert.Equal(uint64(0), sc.Position())
+	sc.Advance(3)
+	assert.Equal(uint64(3), sc.Position())
+	assert.Equal(uint64(5096561930466680017), sc.Uint64())
+
+	// Checking that the increment survives getting and setting states
+	c2 := splitmix64.New(0)
+	c2.SetState(sc.GetState())
+	assert.Equal(uint64(4), c2.Position())
+	assert.Equal(uint64(5586106402394303488), c2.Uint64())
+
+	// Checking that states without the increment use the default increment
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("splitmix64"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	c2.SetState(buf.Bytes())
+	assert.Equal(splitmix64.New(10).Uint64(), c2.Uint64())
+}
+
 // Benchmarks
 func Benchmark_SplitMix64_Uint64(b *testing.B) {
 	rng := splitmix64.New(0)
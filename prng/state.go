@@ -0,0 +1,21 @@
+package prng
+
+// StateError is the error returned by SetStateE (StateRestorer) for a
+// []byte that is not a state of the engine
+type StateError struct {
+	// Algorithm is the name of the algorithm of the engine, as written at
+	// the start of its states
+	Algorithm string
+
+	// Err describes why the []byte is not a state of the engine
+	Err error
+}
+
+// Error returns the message of the error, which is also the message of
+// the panic of SetState
+func (e *StateError) Error() string {
+	return e.Algorithm + ": Error decoding state\n" + e.Err.Error()
+}
+
+// Unwrap returns the reason why the []byte is not a state of the engine
+func (e *StateError) Unwrap() error { return e.Err }
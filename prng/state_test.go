@@ -0,0 +1,227 @@
+package prng_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/aesctr"
+	"github.com/shivakar/random/prng/chacha"
+	"github.com/shivakar/random/prng/dsfmt"
+	"github.com/shivakar/random/prng/jsf64"
+	"github.com/shivakar/random/prng/lehmer"
+	"github.com/shivakar/random/prng/mrg32k3a"
+	"github.com/shivakar/random/prng/mt19937"
+	"github.com/shivakar/random/prng/mt19937ar"
+	"github.com/shivakar/random/prng/pcg64"
+	"github.com/shivakar/random/prng/philox"
+	"github.com/shivakar/random/prng/ranlux"
+	"github.com/shivakar/random/prng/romu"
+	"github.com/shivakar/random/prng/sfc64"
+	"github.com/shivakar/random/prng/sfmt"
+	"github.com/shivakar/random/prng/siphash"
+	"github.com/shivakar/random/prng/splitmix64"
+	"github.com/shivakar/random/prng/tausworthe"
+	"github.com/shivakar/random/prng/threefry"
+	"github.com/shivakar/random/prng/well"
+	"github.com/shivakar/random/prng/wyrand"
+	"github.com/shivakar/random/prng/xoroshiro128plus"
+	"github.com/shivakar/random/prng/xorshift1024star"
+	"github.com/shivakar/random/prng/xorshift128plus"
+	"github.com/shivakar/random/prng/xoshiro256"
+	"github.com/stretchr/testify/assert"
+)
+
+// restorers returns a new instance of every engine, by the name of its
+// algorithm
+func restorers(seed uint64) map[string]prng.Engine {
+	return map[string]prng.Engine{
+		"aesctr":               aesctr.New(seed),
+		"chacha":               chacha.New(seed, 20),
+		"dsfmt19937":           dsfmt.New(seed),
+		"jsf64":                jsf64.New(seed),
+		"lfsr113":              tausworthe.NewLFSR113(seed),
+		"lfsr258":              tausworthe.NewLFSR258(seed),
+		"mcg128":               lehmer.New(seed),
+		"mrg32k3a":             mrg32k3a.New(seed),
+		"mt19937":              mt19937.New(seed),
+		"mt19937ar":            mt19937ar.New(seed),
+		"pcg64":                pcg64.New(seed),
+		"pcg64dxsm":            pcg64.NewDXSM(seed),
+		"philox4x64":           philox.New(seed),
+		"ranlux24":             ranlux.New24(seed, 3),
+		"ranlux48":             ranlux.New48(seed, 1),
+		"romuduojr":            romu.NewDuoJr(seed),
+		"romutrio":             romu.NewTrio(seed),
+		"sfc64":                sfc64.New(seed),
+		"sfmt19937":            sfmt.New(seed),
+		"siphash":              siphash.New(seed),
+		"splitmix64":           splitmix64.New(seed),
+		"taus88":               tausworthe.NewTaus88(seed),
+		"threefry4x64":         threefry.New(seed),
+		"well1024a":            well.New1024a(seed),
+		"well19937c":           well.New19937c(seed),
+		"well512a":             well.New512a(seed),
+		"wyrand":               wyrand.New(seed),
+		"xoroshiro128plus":     xoroshiro128plus.New(seed),
+		"xoroshiro128plusplus": xoroshiro128plus.NewPlusPlus(seed),
+		"xoroshiro128starstar": xoroshiro128plus.NewStarStar(seed),
+		"xorshift1024star":     xorshift1024star.New(seed),
+		"xorshift128plus":      xorshift128plus.New(seed),
+		"xoshiro256":           xoshiro256.New(seed, xoshiro256.StarStar),
+	}
+}
+
+// encode returns the fields encoded as in the states of the engines
+func encode(fields ...interface{}) []byte {
+	buf := new(bytes.Buffer)
+	for _, v := range fields {
+		_ = binary.Write(buf, binary.LittleEndian, v)
+	}
+	return buf.Bytes()
+}
+
+// assertStateError checks that err is a *prng.StateError of the algorithm
+// and that e was left unchanged, i.e. that its state is still want
+func assertStateError(t *testing.T, algo string, e prng.Engine, want []byte, err error) {
+	var se *prng.StateError
+	if assert.True(t, errors.As(err, &se), "%s: %v", algo, err) {
+		assert.Equal(t, algo, se.Algorithm)
+		assert.NotNil(t, errors.Unwrap(err))
+	}
+	assert.Equal(t, want, e.GetState(), algo)
+}
+
+func Test_StateRestorer_RoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	sources := restorers(20170612)
+	for algo, e := range restorers(1) {
+		src := sources[algo]
+		for i := 0; i < 1000; i++ {
+			_ = src.Uint64()
+		}
+		r, ok := e.(prng.StateRestorer)
+		if !assert.True(ok, algo) {
+			continue
+		}
+		assert.NoError(r.SetStateE(src.GetState()), algo)
+		assert.Equal(src.GetState(), e.GetState(), algo)
+		for i := 0; i < 10; i++ {
+			assert.Equal(src.Uint64(), e.Uint64(), algo)
+		}
+	}
+}
+
+func Test_StateRestorer_Length(t *testing.T) {
+	sources := restorers(20170612)
+	for algo, e := range restorers(1) {
+		r := e.(prng.StateRestorer)
+		want := e.GetState()
+		b := sources[algo].GetState()
+
+		// Every truncated state, including an empty one and the bare name
+		for n := 0; n < len(b); n++ {
+			assertStateError(t, algo, e, want, r.SetStateE(b[:n]))
+		}
+		assertStateError(t, algo, e, want, r.SetStateE(nil))
+
+		// Oversized state
+		b = append(b, 0)
+		assertStateError(t, algo, e, want, r.SetStateE(b))
+		assert.Panics(t, func() { e.SetState(b) }, algo)
+	}
+}
+
+func Test_StateRestorer_Algorithm(t *testing.T) {
+	assert := assert.New(t)
+
+	sources := restorers(20170612)
+	for algo, e := range restorers(1) {
+		r := e.(prng.StateRestorer)
+		want := e.GetState()
+		for other, src := range sources {
+			if other == algo {
+				continue
+			}
+			assertStateError(t, algo, e, want, r.SetStateE(src.GetState()))
+		}
+	}
+
+	// Names of algorithms that are a prefix of another one
+	pairs := [][2]string{
+		{"mt19937", "mt19937ar"},
+		{"pcg64", "pcg64dxsm"},
+		{"xoroshiro128plus", "xoroshiro128plusplus"},
+	}
+	for _, p := range pairs {
+		e := sources[p[0]]
+		err := e.(prng.StateRestorer).SetStateE(sources[p[1]].GetState())
+		if assert.Error(err, p[0]) {
+			assert.Equal(p[0]+": Error decoding state\nExpected '"+p[0]+
+				"', got '"+p[1]+"'", err.Error())
+		}
+	}
+}
+
+func Test_StateRestorer_Invariants(t *testing.T) {
+	var zero2 [2]uint64
+	var zero16 [16]uint64
+	var mt [312]uint64
+	mt[0] = 1
+	states := map[string][][]byte{
+		"mt19937": {
+			encode([]byte("mt19937"), uint64(1), uint64(313), mt),
+			encode([]byte("mt19937"), uint64(1), uint64(0), [312]uint64{}),
+			encode([]byte("mt19937"), uint64(1), uint64(0), mt, uint64(0)),
+			encode([]byte("mt19937"), uint64(1), uint64(0), mt, uint64(2),
+				uint64(1)),
+		},
+		"splitmix64": {
+			encode([]byte("splitmix64"), uint64(1), uint64(1), uint64(2)),
+		},
+		"xorshift128plus": {
+			encode([]byte("xorshift128plus"), uint64(1), zero2),
+		},
+		"xorshift1024star": {
+			encode([]byte("xorshift1024star"), uint64(1), uint64(0), zero16),
+			encode([]byte("xorshift1024star"), uint64(1), uint64(16),
+				[16]uint64{1}),
+		},
+		"xoroshiro128plus": {
+			encode([]byte("xoroshiro128plus"), uint64(1), zero2),
+		},
+		"xoroshiro128plusplus": {
+			encode([]byte("xoroshiro128plusplus"), uint64(1), zero2),
+		},
+		"xoroshiro128starstar": {
+			encode([]byte("xoroshiro128starstar"), uint64(1), zero2),
+		},
+		"xoshiro256": {
+			encode([]byte("xoshiro256"), uint64(1), uint64(0), [4]uint64{}),
+			encode([]byte("xoshiro256"), uint64(1), uint64(3), [4]uint64{1}),
+		},
+		"pcg64": {
+			encode([]byte("pcg64"), [6]uint64{1, 0, 1, 1, 0, 2}),
+		},
+		"pcg64dxsm": {
+			encode([]byte("pcg64dxsm"), [6]uint64{1, 0, 1, 1, 0, 2}),
+		},
+		"jsf64": {
+			encode([]byte("jsf64"), [5]uint64{1}),
+		},
+		"mcg128": {
+			encode([]byte("mcg128"), [3]uint64{1, 1, 2}),
+		},
+	}
+	for algo, bs := range states {
+		e := restorers(1)[algo]
+		want := e.GetState()
+		for _, b := range bs {
+			assertStateError(t, algo, e, want, e.(prng.StateRestorer).SetStateE(b))
+			assert.Panics(t, func() { e.SetState(b) }, algo)
+		}
+	}
+}
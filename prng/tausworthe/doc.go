@@ -0,0 +1,38 @@
+// Package tausworthe implements the maximally equidistributed combined
+// Tausworthe pseudo-random number generators of L'Ecuyer:
+//
+//   - Taus88 combines three components and has a period close to 2^88. It
+//     is gsl_rng_taus2 of GSL.
+//   - LFSR113 combines four components and has a period close to 2^113. It
+//     is gsl_rng_taus113 of GSL.
+//   - LFSR258 combines five 64-bit components and has a period close to
+//     2^258.
+//
+// Each component is a linear feedback shift register whose state must not
+// be below a minimum value, 2^(w-k) for a characteristic polynomial of
+// degree k on a word of w bits: the components of taus88 must be greater
+// than 1, 7 and 15, those of lfsr113 greater than 1, 7, 15 and 127 and
+// those of lfsr258 greater than 1, 511, 4095, 131071 and 8388607. Seed
+// skips invalid values, whereas SetSeeds and SetState panic on them.
+// SeedGSL seeds Taus88 and LFSR113 as gsl_rng_set.
+//
+// Taus88 and LFSR113 generate 32-bit values with Uint32; their Uint64
+// combines two values of Uint32, the first one being the most significant.
+//
+// The transition function of each component is linear over GF(2), so the
+// engines implement prng.Advancer: Advance(n) multiplies the state of each
+// component by the n-th power of its transition matrix, computed by
+// repeated squaring in O(w^3 log n) bit operations. Being linear, these
+// generators fail the linear complexity and matrix rank tests of TestU01,
+// and should not be used where this matters.
+//
+// References:
+//
+// P. L'Ecuyer, "Maximally Equidistributed Combined Tausworthe Generators",
+// Mathematics of Computation 65(213) (1996) 203--213.
+//
+// P. L'Ecuyer, "Tables of Maximally-Equidistributed Combined LFSR
+// Generators", Mathematics of Computation 68(225) (1999) 261--269.
+//
+// https://www.iro.umontreal.ca/~lecuyer/papers.html
+package tausworthe
@@ -0,0 +1,230 @@
+package tausworthe
+
+import (
+	"encoding/gob"
+	"strings"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+var (
+	lfsr113 *LFSR113
+	_       prng.Engine        = lfsr113
+	_       prng.Advancer      = lfsr113
+	_       prng.StateRestorer = lfsr113
+)
+
+func init() {
+	prng.Register("lfsr113", func(seed uint64) prng.Engine { return NewLFSR113(seed) })
+	gob.Register(new(LFSR113))
+}
+
+// Components of lfsr113
+var lfsr113Comps = []component{
+	{w: 32, k: 31, q: 6, s: 18},
+	{w: 32, k: 29, q: 2, s: 2},
+	{w: 32, k: 28, q: 13, s: 7},
+	{w: 32, k: 25, q: 3, s: 13},
+}
+
+// LFSR113 implements the maximally equidistributed combined Tausworthe
+// generator lfsr113 of L'Ecuyer, with four components and period close to
+// 2^113. It is the generator gsl_rng_taus113 of GSL.
+type LFSR113 struct {
+	seed  uint64
+	seeds [4]uint32
+	state [4]uint32
+}
+
+// NewLFSR113 returns a new instance of the LFSR113 PRNG Engine.
+// If the seed provided is 0, the engine is initialized with current time
+func NewLFSR113(seed uint64) *LFSR113 {
+	r := new(LFSR113)
+	r.Seed(seed)
+	return r
+}
+
+// Uint32 returns a pseudo-random 32-bit value in [0, 2^32) as a uint32,
+// i.e. the output of lfsr113 of the reference implementation.
+// Uint32 advances the internal state of the engine.
+func (r *LFSR113) Uint32() uint32 {
+	s := &r.state
+	b := ((s[0] << 6) ^ s[0]) >> 13
+	s[0] = ((s[0] & 0xFFFFFFFE) << 18) ^ b
+	b = ((s[1] << 2) ^ s[1]) >> 27
+	s[1] = ((s[1] & 0xFFFFFFF8) << 2) ^ b
+	b = ((s[2] << 13) ^ s[2]) >> 21
+	s[2] = ((s[2] & 0xFFFFFFF0) << 7) ^ b
+	b = ((s[3] << 3) ^ s[3]) >> 12
+	s[3] = ((s[3] & 0xFFFFFF80) << 13) ^ b
+	return s[0] ^ s[1] ^ s[2] ^ s[3]
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64,
+// made of two consecutive values of Uint32, the first one being the most
+// significant.
+// Uint64 advances the internal state of the engine.
+func (r *LFSR113) Uint64() uint64 {
+	hi := uint64(r.Uint32())
+	return hi<<32 | uint64(r.Uint32())
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (r *LFSR113) Float64() float64 {
+	return float64(r.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (r *LFSR113) Float64OO() float64 {
+	return (float64(r.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine. The components
+// of the state are consecutive outputs of SplitMix64 seeded with seed,
+// skipping the outputs that are not valid for a component.
+// If the seed provided is 0, the engine is initialized with current time
+func (r *LFSR113) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	r.seed = seed
+	ms := splitmix64.New(seed)
+	for i, c := range lfsr113Comps {
+		r.seeds[i] = uint32(ms.Uint64())
+		for uint64(r.seeds[i]) < c.min() {
+			r.seeds[i] = uint32(ms.Uint64())
+		}
+	}
+	r.Reset()
+}
+
+// SetSeeds sets the initial state of the engine to the given components,
+// as the variables z1, z2, z3 and z4 of the reference implementation.
+// GetSeed returns 0 after SetSeeds.
+// SetSeeds panics if the components are not greater than 1, 7, 15 and 127
+// respectively.
+func (r *LFSR113) SetSeeds(seeds [4]uint32) {
+	if err := checkSeeds(lfsr113Comps, words(&seeds)); err != nil {
+		panic(strings.Join([]string{"lfsr113: Invalid seeds", err.Error()}, "\n"))
+	}
+	r.seed = 0
+	r.seeds = seeds
+	r.Reset()
+}
+
+// SeedGSL initializes the engine as gsl_rng_set does for gsl_rng_taus113,
+// so that Uint32 generates the same sequence as gsl_rng_get.
+// GetSeed returns 0 after SeedGSL.
+func (r *LFSR113) SeedGSL(seed uint32) {
+	gslSeeds(lfsr113Comps, seed, r.state[:])
+	for i := 0; i < 10; i++ {
+		_ = r.Uint32()
+	}
+	r.seed = 0
+	r.seeds = r.state
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (r *LFSR113) GetSeed() uint64 { return r.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (r *LFSR113) GetState() []byte {
+	return state.Encode("lfsr113", r.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (r *LFSR113) fields() []interface{} {
+	return stateFields(r.seed, r.seeds, r.state)
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (r *LFSR113) SetState(b []byte) {
+	if err := r.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of LFSR113, e.g. if it is truncated or if a
+// component of its state is out of range
+func (r *LFSR113) SetStateE(b []byte) error {
+	return r.setState(state.NewDecoder("lfsr113", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (r *LFSR113) setState(d *state.Decoder) error {
+	var seeds, st [4]uint32
+	seed, err := decodeState(d, lfsr113Comps, &seeds, &st)
+	if err != nil {
+		return err
+	}
+	r.seed = seed
+	r.seeds = seeds
+	r.state = st
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its initial state,
+// keeping the seed
+func (r *LFSR113) Reset() {
+	r.state = r.seeds
+}
+
+// Advance advances the internal state of the engine by n steps, i.e. it is
+// equivalent to calling Uint64 n times, or Uint32 2n times, using powers of
+// the transition matrix of each component over GF(2)
+func (r *LFSR113) Advance(n uint64) {
+	for i, c := range lfsr113Comps {
+		r.state[i] = uint32(c.jump(uint64(r.state[i]), n, 1))
+	}
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (r *LFSR113) MarshalBinary() ([]byte, error) { return r.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (r *LFSR113) UnmarshalBinary(b []byte) error { return r.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (r *LFSR113) MarshalText() ([]byte, error) {
+	return state.MarshalText(r.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (r *LFSR113) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("lfsr113", text)
+	if err != nil {
+		return err
+	}
+	return r.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (r *LFSR113) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("lfsr113", r.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (r *LFSR113) UnmarshalJSON(data []byte) error {
+	return r.setState(state.NewJSONDecoder("lfsr113", data))
+}
@@ -0,0 +1,217 @@
+package tausworthe_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/tausworthe"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadirLFSR113 = filepath.Join("..", "..", "data", "lfsr113")
+
+func Test_LFSR113_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := tausworthe.NewLFSR113(0)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+	r.SetSeeds([4]uint32{2, 8, 16, 128})
+	assert.Zero(r.GetSeed())
+
+	// Checking the minimum values of the components
+	for _, s := range [][4]uint32{{1, 8, 16, 128}, {2, 7, 16, 128}, {2, 8, 15, 128},
+		{2, 8, 16, 127}} {
+		assert.Panics(func() {
+			r.SetSeeds(s)
+		})
+	}
+}
+
+func Test_LFSR113_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed remains same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := tausworthe.NewLFSR113(seed)
+		states[i] = r.GetState()
+	}
+	for i, state := range states {
+		r := tausworthe.NewLFSR113(0)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+	}
+
+	// Checking that the streams remain same after getting and setting states
+	r1 := tausworthe.NewLFSR113(0)
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+	}
+	r2 := tausworthe.NewLFSR113(0)
+	r2.SetState(r1.GetState())
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+		_ = r2.Uint64()
+	}
+	assert.Equal(r1.Uint32(), r2.Uint32())
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	assert.Equal(r1.Float64(), r2.Float64())
+	assert.Equal(r1.Float64OO(), r2.Float64OO())
+	r1.Reset()
+	r2.Reset()
+	assert.Equal(r1.Uint64(), r2.Uint64())
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := tausworthe.NewLFSR113(0)
+		r1.SetState([]byte("Hello"))
+	})
+	assert.Panics(func() {
+		r1 := tausworthe.NewLFSR113(0)
+		r1.SetState(nil)
+	})
+	assert.Panics(func() {
+		r1 := tausworthe.NewLFSR113(0)
+		r1.SetState(tausworthe.NewTaus88(1).GetState())
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("lfsr113"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, [4]uint32{2, 8, 16, 128})
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := tausworthe.NewLFSR113(0)
+		r1.SetState(buf.Bytes())
+	})
+	// Invalid component
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("lfsr113"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, [4]uint32{2, 8, 16, 128})
+	_ = binary.Write(buf, binary.LittleEndian, [4]uint32{2, 7, 16, 128})
+	assert.Panics(func() {
+		r1 := tausworthe.NewLFSR113(0)
+		r1.SetState(buf.Bytes())
+	})
+}
+
+func Test_LFSR113_Uint64(t *testing.T) {
+	e := tausworthe.NewLFSR113(0)
+	filenames := prngtest.GetDataFiles(datadirLFSR113, "lfsr113-*-uint64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_LFSR113_Float64(t *testing.T) {
+	e := tausworthe.NewLFSR113(0)
+	filenames := prngtest.GetDataFiles(datadirLFSR113, "lfsr113-*-float64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_LFSR113_Float64OO(t *testing.T) {
+	e := tausworthe.NewLFSR113(0)
+	filenames := prngtest.GetDataFiles(datadirLFSR113, "lfsr113-*-float64oo-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_LFSR113_Uint32(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking the 1000th output of gsl_rng_taus113 with seed 1, from the
+	// tests of GSL
+	r := tausworthe.NewLFSR113(0)
+	r.SeedGSL(1)
+	for i := 0; i < 999; i++ {
+		_ = r.Uint32()
+	}
+	assert.Equal(uint32(1925420673), r.Uint32())
+
+	// Checking that Uint64 is made of two values of Uint32
+	r1 := tausworthe.NewLFSR113(20170612)
+	r2 := tausworthe.NewLFSR113(20170612)
+	for i := 0; i < 10; i++ {
+		hi := uint64(r2.Uint32())
+		assert.Equal(hi<<32|uint64(r2.Uint32()), r1.Uint64())
+	}
+}
+
+func Test_LFSR113_Advance(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking that Advance matches drawing from the engine
+	r1 := tausworthe.NewLFSR113(20170612)
+	r2 := tausworthe.NewLFSR113(20170612)
+	for _, n := range []uint64{0, 1, 2, 3, 100, 1000} {
+		for i := uint64(0); i < n; i++ {
+			_ = r1.Uint64()
+		}
+		r2.Advance(n)
+		assert.Equal(r1.GetState(), r2.GetState())
+	}
+}
+
+// Benchmarks
+func Benchmark_LFSR113_Uint32(b *testing.B) {
+	rng := tausworthe.NewLFSR113(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint32()
+	}
+}
+
+func Benchmark_LFSR113_Uint64(b *testing.B) {
+	rng := tausworthe.NewLFSR113(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_LFSR113_Float64(b *testing.B) {
+	rng := tausworthe.NewLFSR113(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_LFSR113_Float64OO(b *testing.B) {
+	rng := tausworthe.NewLFSR113(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+func Benchmark_LFSR113_Advance(b *testing.B) {
+	rng := tausworthe.NewLFSR113(0)
+	for i := 0; i < b.N; i++ {
+		rng.Advance(1 << 40)
+	}
+}
+
+// Example - LFSR113 Usage
+func ExampleLFSR113() {
+	// Create a new instance of the lfsr113 engine
+	r := tausworthe.NewLFSR113(20170612)
+
+	fmt.Println("LFSR113: seed = 20170612; Uint32()")
+	for i := 0; i < 3; i++ {
+		// Draw 32 random bits as a uint32
+		fmt.Println(r.Uint32())
+	}
+
+	// Output:
+	// LFSR113: seed = 20170612; Uint32()
+	// 880468113
+	// 625527524
+	// 1337031555
+}
@@ -0,0 +1,213 @@
+package tausworthe
+
+import (
+	"encoding/gob"
+	"strings"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+var (
+	lfsr258 *LFSR258
+	_       prng.Engine        = lfsr258
+	_       prng.Advancer      = lfsr258
+	_       prng.StateRestorer = lfsr258
+)
+
+func init() {
+	prng.Register("lfsr258", func(seed uint64) prng.Engine { return NewLFSR258(seed) })
+	gob.Register(new(LFSR258))
+}
+
+// Components of lfsr258
+var lfsr258Comps = []component{
+	{w: 64, k: 63, q: 1, s: 10},
+	{w: 64, k: 55, q: 24, s: 5},
+	{w: 64, k: 52, q: 3, s: 29},
+	{w: 64, k: 47, q: 5, s: 23},
+	{w: 64, k: 41, q: 3, s: 8},
+}
+
+// LFSR258 implements the maximally equidistributed 64-bit combined
+// Tausworthe generator lfsr258 of L'Ecuyer, with five components and period
+// close to 2^258
+type LFSR258 struct {
+	seed  uint64
+	seeds [5]uint64
+	state [5]uint64
+}
+
+// NewLFSR258 returns a new instance of the LFSR258 PRNG Engine.
+// If the seed provided is 0, the engine is initialized with current time
+func NewLFSR258(seed uint64) *LFSR258 {
+	r := new(LFSR258)
+	r.Seed(seed)
+	return r
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64,
+// i.e. the output of lfsr258 of the reference implementation before its
+// conversion to a float64.
+// Uint64 advances the internal state of the engine.
+func (r *LFSR258) Uint64() uint64 {
+	s := &r.state
+	b := ((s[0] << 1) ^ s[0]) >> 53
+	s[0] = ((s[0] & 0xFFFFFFFFFFFFFFFE) << 10) ^ b
+	b = ((s[1] << 24) ^ s[1]) >> 50
+	s[1] = ((s[1] & 0xFFFFFFFFFFFFFE00) << 5) ^ b
+	b = ((s[2] << 3) ^ s[2]) >> 23
+	s[2] = ((s[2] & 0xFFFFFFFFFFFFF000) << 29) ^ b
+	b = ((s[3] << 5) ^ s[3]) >> 24
+	s[3] = ((s[3] & 0xFFFFFFFFFFFE0000) << 23) ^ b
+	b = ((s[4] << 3) ^ s[4]) >> 33
+	s[4] = ((s[4] & 0xFFFFFFFFFF800000) << 8) ^ b
+	return s[0] ^ s[1] ^ s[2] ^ s[3] ^ s[4]
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (r *LFSR258) Float64() float64 {
+	return float64(r.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (r *LFSR258) Float64OO() float64 {
+	return (float64(r.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine. The components
+// of the state are consecutive outputs of SplitMix64 seeded with seed,
+// skipping the outputs that are not valid for a component.
+// If the seed provided is 0, the engine is initialized with current time
+func (r *LFSR258) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	r.seed = seed
+	ms := splitmix64.New(seed)
+	for i, c := range lfsr258Comps {
+		r.seeds[i] = ms.Uint64()
+		for r.seeds[i] < c.min() {
+			r.seeds[i] = ms.Uint64()
+		}
+	}
+	r.Reset()
+}
+
+// SetSeeds sets the initial state of the engine to the given components,
+// as the variables y1, y2, y3, y4 and y5 of the reference implementation.
+// GetSeed returns 0 after SetSeeds.
+// SetSeeds panics if the components are not greater than 1, 511, 4095,
+// 131071 and 8388607 respectively.
+func (r *LFSR258) SetSeeds(seeds [5]uint64) {
+	if err := checkSeeds(lfsr258Comps, seeds[:]); err != nil {
+		panic(strings.Join([]string{"lfsr258: Invalid seeds", err.Error()}, "\n"))
+	}
+	r.seed = 0
+	r.seeds = seeds
+	r.Reset()
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (r *LFSR258) GetSeed() uint64 { return r.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (r *LFSR258) GetState() []byte {
+	return state.Encode("lfsr258", r.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (r *LFSR258) fields() []interface{} {
+	return stateFields(r.seed, r.seeds, r.state)
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (r *LFSR258) SetState(b []byte) {
+	if err := r.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of LFSR258, e.g. if it is truncated or if a
+// component of its state is out of range
+func (r *LFSR258) SetStateE(b []byte) error {
+	return r.setState(state.NewDecoder("lfsr258", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (r *LFSR258) setState(d *state.Decoder) error {
+	var seeds, st [5]uint64
+	seed, err := decodeState(d, lfsr258Comps, &seeds, &st)
+	if err != nil {
+		return err
+	}
+	r.seed = seed
+	r.seeds = seeds
+	r.state = st
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its initial state,
+// keeping the seed
+func (r *LFSR258) Reset() {
+	r.state = r.seeds
+}
+
+// Advance advances the internal state of the engine by n steps, i.e. it is
+// equivalent to calling Uint64 n times, using powers of the transition
+// matrix of each component over GF(2)
+func (r *LFSR258) Advance(n uint64) {
+	for i, c := range lfsr258Comps {
+		r.state[i] = c.jump(r.state[i], n, 0)
+	}
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (r *LFSR258) MarshalBinary() ([]byte, error) { return r.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (r *LFSR258) UnmarshalBinary(b []byte) error { return r.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (r *LFSR258) MarshalText() ([]byte, error) {
+	return state.MarshalText(r.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (r *LFSR258) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("lfsr258", text)
+	if err != nil {
+		return err
+	}
+	return r.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (r *LFSR258) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("lfsr258", r.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (r *LFSR258) UnmarshalJSON(data []byte) error {
+	return r.setState(state.NewJSONDecoder("lfsr258", data))
+}
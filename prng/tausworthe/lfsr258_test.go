@@ -0,0 +1,193 @@
+package tausworthe_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/tausworthe"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadirLFSR258 = filepath.Join("..", "..", "data", "lfsr258")
+
+func Test_LFSR258_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := tausworthe.NewLFSR258(0)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+	r.SetSeeds([5]uint64{2, 512, 4096, 131072, 8388608})
+	assert.Zero(r.GetSeed())
+
+	// Checking the minimum values of the components
+	for _, s := range [][5]uint64{
+		{1, 512, 4096, 131072, 8388608},
+		{2, 511, 4096, 131072, 8388608},
+		{2, 512, 4095, 131072, 8388608},
+		{2, 512, 4096, 131071, 8388608},
+		{2, 512, 4096, 131072, 8388607},
+	} {
+		assert.Panics(func() {
+			r.SetSeeds(s)
+		})
+	}
+}
+
+func Test_LFSR258_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed remains same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := tausworthe.NewLFSR258(seed)
+		states[i] = r.GetState()
+	}
+	for i, state := range states {
+		r := tausworthe.NewLFSR258(0)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+	}
+
+	// Checking that the streams remain same after getting and setting states
+	r1 := tausworthe.NewLFSR258(0)
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+	}
+	r2 := tausworthe.NewLFSR258(0)
+	r2.SetState(r1.GetState())
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+		_ = r2.Uint64()
+	}
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	assert.Equal(r1.Float64(), r2.Float64())
+	assert.Equal(r1.Float64OO(), r2.Float64OO())
+	r1.Reset()
+	r2.Reset()
+	assert.Equal(r1.Uint64(), r2.Uint64())
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := tausworthe.NewLFSR258(0)
+		r1.SetState([]byte("Hello"))
+	})
+	assert.Panics(func() {
+		r1 := tausworthe.NewLFSR258(0)
+		r1.SetState(nil)
+	})
+	assert.Panics(func() {
+		r1 := tausworthe.NewLFSR258(0)
+		r1.SetState(tausworthe.NewTaus88(1).GetState())
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("lfsr258"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, [5]uint64{2, 512, 4096, 131072, 8388608})
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := tausworthe.NewLFSR258(0)
+		r1.SetState(buf.Bytes())
+	})
+	// Invalid component
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("lfsr258"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, [5]uint64{2, 512, 4096, 131072, 8388608})
+	_ = binary.Write(buf, binary.LittleEndian, [5]uint64{2, 512, 4095, 131072, 8388608})
+	assert.Panics(func() {
+		r1 := tausworthe.NewLFSR258(0)
+		r1.SetState(buf.Bytes())
+	})
+}
+
+func Test_LFSR258_Uint64(t *testing.T) {
+	e := tausworthe.NewLFSR258(0)
+	filenames := prngtest.GetDataFiles(datadirLFSR258, "lfsr258-*-uint64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_LFSR258_Float64(t *testing.T) {
+	e := tausworthe.NewLFSR258(0)
+	filenames := prngtest.GetDataFiles(datadirLFSR258, "lfsr258-*-float64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_LFSR258_Float64OO(t *testing.T) {
+	e := tausworthe.NewLFSR258(0)
+	filenames := prngtest.GetDataFiles(datadirLFSR258, "lfsr258-*-float64oo-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_LFSR258_Advance(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking that Advance matches drawing from the engine
+	r1 := tausworthe.NewLFSR258(20170612)
+	r2 := tausworthe.NewLFSR258(20170612)
+	for _, n := range []uint64{0, 1, 2, 3, 100, 1000} {
+		for i := uint64(0); i < n; i++ {
+			_ = r1.Uint64()
+		}
+		r2.Advance(n)
+		assert.Equal(r1.GetState(), r2.GetState())
+	}
+}
+
+// Benchmarks
+func Benchmark_LFSR258_Uint64(b *testing.B) {
+	rng := tausworthe.NewLFSR258(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_LFSR258_Float64(b *testing.B) {
+	rng := tausworthe.NewLFSR258(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_LFSR258_Float64OO(b *testing.B) {
+	rng := tausworthe.NewLFSR258(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+func Benchmark_LFSR258_Advance(b *testing.B) {
+	rng := tausworthe.NewLFSR258(0)
+	for i := 0; i < b.N; i++ {
+		rng.Advance(1 << 40)
+	}
+}
+
+// Example - LFSR258 Usage
+func ExampleLFSR258() {
+	// Create a new instance of the lfsr258 engine
+	r := tausworthe.NewLFSR258(20170612)
+
+	fmt.Println("LFSR258: seed = 20170612; Uint64()")
+	for i := 0; i < 3; i++ {
+		// Draw 64 random bits as a uint64
+		fmt.Println(r.Uint64())
+	}
+
+	// Output:
+	// LFSR258: seed = 20170612; Uint64()
+	// 5162531570159666069
+	// 2281244360640967877
+	// 18011517688295294544
+}
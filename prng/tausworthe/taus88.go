@@ -0,0 +1,227 @@
+package tausworthe
+
+import (
+	"encoding/gob"
+	"strings"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+var (
+	taus88 *Taus88
+	_      prng.Engine        = taus88
+	_      prng.Advancer      = taus88
+	_      prng.StateRestorer = taus88
+)
+
+func init() {
+	prng.Register("taus88", func(seed uint64) prng.Engine { return NewTaus88(seed) })
+	gob.Register(new(Taus88))
+}
+
+// Components of taus88
+var taus88Comps = []component{
+	{w: 32, k: 31, q: 13, s: 12},
+	{w: 32, k: 29, q: 2, s: 4},
+	{w: 32, k: 28, q: 3, s: 17},
+}
+
+// Taus88 implements the maximally equidistributed combined Tausworthe
+// generator taus88 of L'Ecuyer, with three components and period close to
+// 2^88. It is the generator gsl_rng_taus2 of GSL.
+type Taus88 struct {
+	seed  uint64
+	seeds [3]uint32
+	state [3]uint32
+}
+
+// NewTaus88 returns a new instance of the Taus88 PRNG Engine.
+// If the seed provided is 0, the engine is initialized with current time
+func NewTaus88(seed uint64) *Taus88 {
+	r := new(Taus88)
+	r.Seed(seed)
+	return r
+}
+
+// Uint32 returns a pseudo-random 32-bit value in [0, 2^32) as a uint32,
+// i.e. the output of taus88 of the reference implementation.
+// Uint32 advances the internal state of the engine.
+func (r *Taus88) Uint32() uint32 {
+	s := &r.state
+	b := ((s[0] << 13) ^ s[0]) >> 19
+	s[0] = ((s[0] & 0xFFFFFFFE) << 12) ^ b
+	b = ((s[1] << 2) ^ s[1]) >> 25
+	s[1] = ((s[1] & 0xFFFFFFF8) << 4) ^ b
+	b = ((s[2] << 3) ^ s[2]) >> 11
+	s[2] = ((s[2] & 0xFFFFFFF0) << 17) ^ b
+	return s[0] ^ s[1] ^ s[2]
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64,
+// made of two consecutive values of Uint32, the first one being the most
+// significant.
+// Uint64 advances the internal state of the engine.
+func (r *Taus88) Uint64() uint64 {
+	hi := uint64(r.Uint32())
+	return hi<<32 | uint64(r.Uint32())
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (r *Taus88) Float64() float64 {
+	return float64(r.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (r *Taus88) Float64OO() float64 {
+	return (float64(r.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine. The components
+// of the state are consecutive outputs of SplitMix64 seeded with seed,
+// skipping the outputs that are not valid for a component.
+// If the seed provided is 0, the engine is initialized with current time
+func (r *Taus88) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	r.seed = seed
+	ms := splitmix64.New(seed)
+	for i, c := range taus88Comps {
+		r.seeds[i] = uint32(ms.Uint64())
+		for uint64(r.seeds[i]) < c.min() {
+			r.seeds[i] = uint32(ms.Uint64())
+		}
+	}
+	r.Reset()
+}
+
+// SetSeeds sets the initial state of the engine to the given components,
+// as the variables s1, s2 and s3 of the reference implementation.
+// GetSeed returns 0 after SetSeeds.
+// SetSeeds panics if the components are not greater than 1, 7 and 15
+// respectively.
+func (r *Taus88) SetSeeds(seeds [3]uint32) {
+	if err := checkSeeds(taus88Comps, words(&seeds)); err != nil {
+		panic(strings.Join([]string{"taus88: Invalid seeds", err.Error()}, "\n"))
+	}
+	r.seed = 0
+	r.seeds = seeds
+	r.Reset()
+}
+
+// SeedGSL initializes the engine as gsl_rng_set does for gsl_rng_taus2,
+// so that Uint32 generates the same sequence as gsl_rng_get.
+// GetSeed returns 0 after SeedGSL.
+func (r *Taus88) SeedGSL(seed uint32) {
+	gslSeeds(taus88Comps, seed, r.state[:])
+	for i := 0; i < 6; i++ {
+		_ = r.Uint32()
+	}
+	r.seed = 0
+	r.seeds = r.state
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (r *Taus88) GetSeed() uint64 { return r.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (r *Taus88) GetState() []byte {
+	return state.Encode("taus88", r.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (r *Taus88) fields() []interface{} {
+	return stateFields(r.seed, r.seeds, r.state)
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (r *Taus88) SetState(b []byte) {
+	if err := r.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of Taus88, e.g. if it is truncated or if a
+// component of its state is out of range
+func (r *Taus88) SetStateE(b []byte) error {
+	return r.setState(state.NewDecoder("taus88", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (r *Taus88) setState(d *state.Decoder) error {
+	var seeds, st [3]uint32
+	seed, err := decodeState(d, taus88Comps, &seeds, &st)
+	if err != nil {
+		return err
+	}
+	r.seed = seed
+	r.seeds = seeds
+	r.state = st
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its initial state,
+// keeping the seed
+func (r *Taus88) Reset() {
+	r.state = r.seeds
+}
+
+// Advance advances the internal state of the engine by n steps, i.e. it is
+// equivalent to calling Uint64 n times, or Uint32 2n times, using powers of
+// the transition matrix of each component over GF(2)
+func (r *Taus88) Advance(n uint64) {
+	for i, c := range taus88Comps {
+		r.state[i] = uint32(c.jump(uint64(r.state[i]), n, 1))
+	}
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (r *Taus88) MarshalBinary() ([]byte, error) { return r.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (r *Taus88) UnmarshalBinary(b []byte) error { return r.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (r *Taus88) MarshalText() ([]byte, error) {
+	return state.MarshalText(r.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (r *Taus88) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("taus88", text)
+	if err != nil {
+		return err
+	}
+	return r.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (r *Taus88) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("taus88", r.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (r *Taus88) UnmarshalJSON(data []byte) error {
+	return r.setState(state.NewJSONDecoder("taus88", data))
+}
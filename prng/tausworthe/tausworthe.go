@@ -0,0 +1,154 @@
+package tausworthe
+
+import (
+	"fmt"
+
+	"github.com/shivakar/random/prng/internal/state"
+)
+
+// component holds the parameters of a Tausworthe generator with a
+// characteristic polynomial of degree k, computing one step of the
+// recurrence on a word of w bits with the shifts q and s
+type component struct {
+	w, k, q, s uint
+}
+
+// min returns the smallest valid state of the component. The k most
+// significant bits of the state must not all be zero.
+func (c component) min() uint64 {
+	return uint64(1) << (c.w - c.k)
+}
+
+// step returns the state following z
+func (c component) step(z uint64) uint64 {
+	m := ^uint64(0) >> (64 - c.w)
+	mask := (m << (c.w - c.k)) & m
+	b := (((z << c.q) & m) ^ z) >> (c.k - c.s)
+	return (((z & mask) << c.s) & m) ^ b
+}
+
+// matrix is a square matrix over GF(2) of size at most 64, whose j-th
+// element is the j-th column, i.e. the image of the j-th unit vector
+type matrix [64]uint64
+
+// transition returns the matrix of step, which is linear over GF(2)
+func (c component) transition() *matrix {
+	t := new(matrix)
+	for j := uint(0); j < c.w; j++ {
+		t[j] = c.step(uint64(1) << j)
+	}
+	return t
+}
+
+// apply returns the product of the matrix and the vector v
+func (m *matrix) apply(v uint64) uint64 {
+	var r uint64
+	for j := 0; v != 0; j, v = j+1, v>>1 {
+		if v&1 != 0 {
+			r ^= m[j]
+		}
+	}
+	return r
+}
+
+// square returns the square of the matrix
+func (m *matrix) square() *matrix {
+	r := new(matrix)
+	for j := range m {
+		r[j] = m.apply(m[j])
+	}
+	return r
+}
+
+// jump returns the state of the component n*2^e steps after z, computing
+// T^(n*2^e) z, where T is the transition matrix of the component, with
+// O(log n + e) squarings of T
+func (c component) jump(z uint64, n uint64, e uint) uint64 {
+	t := c.transition()
+	for i := uint(0); i < e; i++ {
+		t = t.square()
+	}
+	for ; n != 0; n >>= 1 {
+		if n&1 != 0 {
+			z = t.apply(z)
+		}
+		if n > 1 {
+			t = t.square()
+		}
+	}
+	return z
+}
+
+// checkSeeds checks that each component of the state s is valid
+func checkSeeds(comps []component, s []uint64) error {
+	for i, c := range comps {
+		if s[i] < c.min() || s[i]>>c.w != 0 {
+			return fmt.Errorf("Seed[%d] = %d is not in [%d, 2^%d)", i, s[i],
+				c.min(), c.w)
+		}
+	}
+	return nil
+}
+
+// stateFields returns the fields of the state of a Tausworthe engine: the
+// seed, the initial state and the state
+func stateFields(seed uint64, seeds, st interface{}) []interface{} {
+	return []interface{}{
+		uint64(seed),
+		seeds,
+		st,
+	}
+}
+
+// decodeState decodes the initial state and the state of stateFields by d
+// into seeds and state, which must be pointers to arrays of words, checks
+// them and returns the seed, or an error if the state is invalid
+func decodeState(d *state.Decoder, comps []component, seeds, st interface{}) (uint64, error) {
+	var seed uint64
+	d.Read(&seed, seeds, st)
+	for _, v := range []interface{}{seeds, st} {
+		if err := checkSeeds(comps, words(v)); err != nil {
+			d.Fail(err)
+		}
+	}
+	return seed, d.Err()
+}
+
+// words returns the words of a pointer to an array of uint32 or uint64 as
+// a []uint64
+func words(v interface{}) []uint64 {
+	var s []uint64
+	switch a := v.(type) {
+	case *[3]uint32:
+		for _, w := range a {
+			s = append(s, uint64(w))
+		}
+	case *[4]uint32:
+		for _, w := range a {
+			s = append(s, uint64(w))
+		}
+	case *[5]uint64:
+		s = append(s, a[:]...)
+	}
+	return s
+}
+
+// lcg is the linear congruential generator used by GSL to derive the
+// components of the state from a seed
+func lcg(n uint32) uint32 {
+	return 69069 * n
+}
+
+// gslSeeds sets s to the initial state derived from seed by GSL
+func gslSeeds(comps []component, seed uint32, s []uint32) {
+	if seed == 0 {
+		seed = 1
+	}
+	for i, c := range comps {
+		seed = lcg(seed)
+		if uint64(seed) < c.min() {
+			seed += uint32(c.min())
+		}
+		s[i] = seed
+	}
+}
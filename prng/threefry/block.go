@@ -0,0 +1,55 @@
+package threefry
+
+import "math/bits"
+
+// Rounds is the number of rounds of the Threefry4x64 bijection used by the
+// engine and by At, as recommended by Random123
+const Rounds = 20
+
+// parity is the key schedule constant of Threefish
+const parity = 0x1BD11BDAA9FC1A22
+
+// rotations are the rotation constants of Threefry4x64, indexed by round
+// modulo 8
+var rotations = [8][2]int{
+	{14, 16}, {52, 57}, {23, 40}, {5, 37},
+	{25, 33}, {46, 12}, {58, 22}, {32, 32},
+}
+
+// At returns the output of Threefry4x64-20 for the given key and counter.
+// At has no state: the same key and counter always yield the same output,
+// which is the block generated by an engine with that key when its counter
+// equals counter.
+func At(key [4]uint64, counter [4]uint64) [4]uint64 {
+	ks := [5]uint64{key[0], key[1], key[2], key[3], parity}
+	for _, k := range key {
+		ks[4] ^= k
+	}
+	x := counter
+	for i := range x {
+		x[i] += ks[i]
+	}
+	for r := 0; r < Rounds; r++ {
+		rot := rotations[r%8]
+		if r%2 == 0 {
+			x[0] += x[1]
+			x[1] = bits.RotateLeft64(x[1], rot[0]) ^ x[0]
+			x[2] += x[3]
+			x[3] = bits.RotateLeft64(x[3], rot[1]) ^ x[2]
+		} else {
+			x[0] += x[3]
+			x[3] = bits.RotateLeft64(x[3], rot[0]) ^ x[0]
+			x[2] += x[1]
+			x[1] = bits.RotateLeft64(x[1], rot[1]) ^ x[2]
+		}
+		// Key injection after every four rounds
+		if r%4 == 3 {
+			s := uint64(r+1) / 4
+			for i := range x {
+				x[i] += ks[(s+uint64(i))%5]
+			}
+			x[3] += s
+		}
+	}
+	return x
+}
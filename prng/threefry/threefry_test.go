@@ -0,0 +1,258 @@
+package threefry_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/threefry"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadir = filepath.Join("..", "..", "data", "threefry")
+var longTest bool
+
+// TestMain is the entry point for testing
+func TestMain(m *testing.M) {
+	longTest = prngtest.ParseCommandLine()
+	os.Exit(m.Run())
+}
+
+func Test_Threefry4x64_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := threefry.New(0)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+}
+
+func Test_Threefry4x64_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed remains same after getting and setting states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := threefry.New(seed)
+		states[i] = r.GetState()
+	}
+	for i, state := range states {
+		r := threefry.New(0)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+	}
+
+	// Checking that the streams remain same after getting and setting
+	// states, at the start of a block and within a block
+	for _, n := range []int{0, 3, 4, 13} {
+		r1 := threefry.New(0)
+		for i := 0; i < n; i++ {
+			_ = r1.Uint64()
+		}
+		r2 := threefry.New(0)
+		r2.SetState(r1.GetState())
+		for i := 0; i < 10; i++ {
+			assert.Equal(r1.Uint64(), r2.Uint64())
+		}
+		assert.Equal(r1.Float64(), r2.Float64())
+		assert.Equal(r1.Float64OO(), r2.Float64OO())
+	}
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := threefry.New(0)
+		r1.SetState([]byte("Hello"))
+	})
+	assert.Panics(func() {
+		r1 := threefry.New(0)
+		r1.SetState(nil)
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("threefry2x64"))
+	assert.Panics(func() {
+		r1 := threefry.New(0)
+		r1.SetState(buf.Bytes())
+	})
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("threefry4x64"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, [4]uint64{})
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := threefry.New(0)
+		r1.SetState(buf.Bytes())
+	})
+
+	// Position beyond the end of a block
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("threefry4x64"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, [4]uint64{})
+	_ = binary.Write(buf, binary.LittleEndian, [4]uint64{})
+	_ = binary.Write(buf, binary.LittleEndian, uint64(4))
+	assert.Panics(func() {
+		r1 := threefry.New(0)
+		r1.SetState(buf.Bytes())
+	})
+}
+
+func Test_Threefry4x64_Uint64(t *testing.T) {
+	e := threefry.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "*uint64*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_Threefry4x64_Float64(t *testing.T) {
+	e := threefry.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "*float64*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_Threefry4x64_Float64OO(t *testing.T) {
+	e := threefry.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "*float64oo*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_Threefry4x64_KnownAnswers(t *testing.T) {
+	assert := assert.New(t)
+
+	// Known-answer tests for threefry4x64 with 20 rounds from kat_vectors
+	// of Random123
+	f := ^uint64(0)
+	tests := []struct {
+		key      [4]uint64
+		counter  [4]uint64
+		expected [4]uint64
+	}{
+		{[4]uint64{0, 0, 0, 0}, [4]uint64{0, 0, 0, 0},
+			[4]uint64{0x09218ebde6c85537, 0x55941f5266d86105,
+				0x4bd25e16282434dc, 0xee29ec846bd2e40b}},
+		{[4]uint64{f, f, f, f}, [4]uint64{f, f, f, f},
+			[4]uint64{0x29c24097942bba1b, 0x0371bbfb0f6f4e11,
+				0x3c231ffa33f83a1c, 0xcd29113fde32d168}},
+	}
+	for _, test := range tests {
+		assert.Equal(test.expected, threefry.At(test.key, test.counter))
+
+		r := threefry.NewWithKey(test.key)
+		assert.Zero(r.GetSeed())
+		assert.Equal(test.key, r.Key())
+		r.Seek(test.counter)
+		for _, v := range test.expected {
+			assert.Equal(v, r.Uint64())
+		}
+	}
+}
+
+func Test_Threefry4x64_AdvanceSeek(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking Advance against drawing, from every position within a block
+	steps := []uint64{0, 1, 3, 4, 5, 17, 1024, 674637}
+	for _, n := range steps {
+		for offset := 0; offset < 4; offset++ {
+			r1 := threefry.New(20170612)
+			r2 := threefry.New(20170612)
+			for i := 0; i < offset; i++ {
+				_ = r1.Uint64()
+				_ = r2.Uint64()
+			}
+			r1.Advance(n)
+			for i := uint64(0); i < n; i++ {
+				_ = r2.Uint64()
+			}
+			assert.Equal(r2.Counter(), r1.Counter())
+			assert.Equal(r2.Uint64(), r1.Uint64())
+		}
+	}
+
+	// Checking that the counter carries into its upper words
+	f := ^uint64(0)
+	r := threefry.New(20170612)
+	r.Seek([4]uint64{f, f, 0, 0})
+	r.Advance(5)
+	assert.Equal([4]uint64{0, 0, 1, 0}, r.Counter())
+	r.Seek([4]uint64{f, f, f, f})
+	r.Advance(4)
+	assert.Equal([4]uint64{}, r.Counter())
+
+	// Checking that Reset and SetKey rewind the engine
+	r1 := threefry.New(20170612)
+	v := r1.Uint64()
+	r1.Advance(100)
+	r1.Reset()
+	assert.Equal(v, r1.Uint64())
+	r2 := threefry.New(1)
+	r2.SetKey(r1.Key())
+	assert.Equal(uint64(1), r2.GetSeed())
+	assert.Equal(v, r2.Uint64())
+}
+
+// Benchmarks
+func Benchmark_Threefry4x64_Uint64(b *testing.B) {
+	rng := threefry.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_Threefry4x64_Float64(b *testing.B) {
+	rng := threefry.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_Threefry4x64_Float64OO(b *testing.B) {
+	rng := threefry.New(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+func Benchmark_Threefry4x64_At(b *testing.B) {
+	key := [4]uint64{1, 2, 3, 4}
+	for i := 0; i < b.N; i++ {
+		_ = threefry.At(key, [4]uint64{uint64(i), 0, 0, 0})
+	}
+}
+
+// Example - Threefry4x64 Usage
+func ExampleThreefry4x64() {
+	// Create a new instance of the Threefry4x64 engine
+	r := threefry.New(20170612)
+
+	fmt.Println("Threefry4x64: seed = 20170612; Uint64()")
+	for i := 0; i < 3; i++ {
+		// Draw 64 random bits as a uint64
+		fmt.Println(r.Uint64())
+	}
+
+	// Output:
+	// Threefry4x64: seed = 20170612; Uint64()
+	// 2454804937103137186
+	// 5651196540786231311
+	// 15051884637777284254
+}
+
+// Example - Stateless use of Threefry4x64
+func ExampleAt() {
+	// The output only depends on the key and the counter, e.g. the
+	// index of a work item in a parallel computation
+	key := [4]uint64{20170612, 0, 0, 0}
+	fmt.Println(threefry.At(key, [4]uint64{42, 0, 0, 0})[0])
+
+	// Output:
+	// 623887188576199405
+}
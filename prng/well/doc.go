@@ -0,0 +1,27 @@
+// Package well implements Well-Equidistributed Long-period Linear (WELL)
+// pseudo-random number generators of Panneton, L'Ecuyer and Matsumoto:
+//
+//   - WELL512a, with 512 bits of state and period 2^512-1
+//   - WELL1024a, with 1024 bits of state and period 2^1024-1
+//   - WELL19937c, with period 2^19937-1, the variant of WELL19937a whose
+//     output is tempered to make it maximally equidistributed
+//
+// Like MT19937, WELL generators are linear over GF(2), but their
+// transition matrices are much denser. They recover from states with few
+// non-zero bits, e.g. after a poor initialization, within a few thousand
+// draws instead of the hundreds of thousands required by MT19937.
+//
+// The engines generate 32-bit values with Uint32, matching the outputs of
+// the reference implementations before their conversion to float64. Uint64
+// combines two values of Uint32, the first one being the most significant.
+// Seed fills the state from SplitMix64; the reference implementations leave
+// seeding to the user.
+//
+// References:
+//
+// F. Panneton, P. L'Ecuyer and M. Matsumoto, "Improved Long-Period
+// Generators Based on Linear Recurrences Modulo 2", ACM Transactions on
+// Mathematical Software 32(1) (2006) 1--16.
+//
+// https://www.iro.umontreal.ca/~panneton/WELLRNG.html
+package well
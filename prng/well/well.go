@@ -0,0 +1,61 @@
+package well
+
+import (
+	"fmt"
+
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+// seedState fills the words of state with consecutive outputs of
+// SplitMix64 seeded with seed, the least significant half of each output
+// first, drawing again in the unlikely case that they are all zero
+func seedState(state []uint32, seed uint64) {
+	ms := splitmix64.New(seed)
+	for {
+		var or uint32
+		for i := 0; i < len(state); i += 2 {
+			v := ms.Uint64()
+			state[i] = uint32(v)
+			state[i+1] = uint32(v >> 32)
+			or |= state[i] | state[i+1]
+		}
+		if or != 0 {
+			return
+		}
+	}
+}
+
+// stateFields returns the fields of the state of a WELL engine: the seed,
+// the index and the state words
+func stateFields(seed uint64, index int, st []uint32) []interface{} {
+	return []interface{}{
+		uint64(seed),
+		uint64(index),
+		st,
+	}
+}
+
+// decodeState decodes the fields of stateFields by d into st, and returns
+// the seed and the index, or an error if the state is invalid. The index must be a valid index of st, and the state
+// words must not be all zero.
+func decodeState(d *state.Decoder, st []uint32) (uint64, int, error) {
+	var seed, idx uint64
+	s := make([]uint32, len(st))
+	d.Read(&seed, &idx, s)
+	if idx >= uint64(len(st)) {
+		d.Fail(fmt.Errorf("Invalid index %d", idx))
+	}
+	var or uint32
+	for _, v := range s {
+		or |= v
+	}
+	if or == 0 {
+		d.Fail(fmt.Errorf("State must not be all zero"))
+	}
+	if err := d.Err(); err != nil {
+		return 0, 0, err
+	}
+	copy(st, s)
+	return seed, int(idx), nil
+}
@@ -0,0 +1,171 @@
+package well
+
+import (
+	"encoding/gob"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+)
+
+var (
+	well1024a *WELL1024a
+	_         prng.Engine        = well1024a
+	_         prng.StateRestorer = well1024a
+)
+
+func init() {
+	prng.Register("well1024a", func(seed uint64) prng.Engine { return New1024a(seed) })
+	gob.Register(new(WELL1024a))
+}
+
+// WELL1024a implements the WELL1024a PRNG with 1024 bits of state and period
+// 2^1024-1
+type WELL1024a struct {
+	seed  uint64
+	index int
+	state [32]uint32
+}
+
+// New1024a returns a new instance of the WELL1024a PRNG Engine.
+// If the seed provided is 0, the engine is initialized with current time
+func New1024a(seed uint64) *WELL1024a {
+	r := new(WELL1024a)
+	r.Seed(seed)
+	return r
+}
+
+// Uint32 returns a pseudo-random 32-bit value in [0, 2^32) as a uint32,
+// i.e. the output of WELLRNG1024a of the reference implementation before
+// its conversion to a float64.
+// Uint32 advances the internal state of the engine.
+func (r *WELL1024a) Uint32() uint32 {
+	s, i := &r.state, r.index
+	z0 := s[(i+31)&31]
+	vm1, vm2, vm3 := s[(i+3)&31], s[(i+24)&31], s[(i+10)&31]
+	z1 := s[i] ^ vm1 ^ (vm1 >> 8)
+	z2 := vm2 ^ (vm2 << 19) ^ vm3 ^ (vm3 << 14)
+	s[i] = z1 ^ z2
+	r.index = (i + 31) & 31
+	s[r.index] = z0 ^ (z0 << 11) ^ z1 ^ (z1 << 7) ^ z2 ^ (z2 << 13)
+	return s[r.index]
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64,
+// made of two consecutive values of Uint32, the first one being the most
+// significant.
+// Uint64 advances the internal state of the engine.
+func (r *WELL1024a) Uint64() uint64 {
+	hi := uint64(r.Uint32())
+	return hi<<32 | uint64(r.Uint32())
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (r *WELL1024a) Float64() float64 {
+	return float64(r.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (r *WELL1024a) Float64OO() float64 {
+	return (float64(r.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine
+func (r *WELL1024a) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	r.seed = seed
+	r.index = 0
+	seedState(r.state[:], seed)
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (r *WELL1024a) GetSeed() uint64 { return r.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (r *WELL1024a) GetState() []byte {
+	return state.Encode("well1024a", r.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (r *WELL1024a) fields() []interface{} {
+	return stateFields(r.seed, r.index, r.state[:])
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (r *WELL1024a) SetState(b []byte) {
+	if err := r.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of WELL1024a, e.g. if it is truncated, if its
+// index is out of range or if its state is all zero
+func (r *WELL1024a) SetStateE(b []byte) error {
+	return r.setState(state.NewDecoder("well1024a", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (r *WELL1024a) setState(d *state.Decoder) error {
+	seed, index, err := decodeState(d, r.state[:])
+	if err != nil {
+		return err
+	}
+	r.seed, r.index = seed, index
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed
+func (r *WELL1024a) Reset() {
+	r.Seed(r.seed)
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (r *WELL1024a) MarshalBinary() ([]byte, error) { return r.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (r *WELL1024a) UnmarshalBinary(b []byte) error { return r.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (r *WELL1024a) MarshalText() ([]byte, error) {
+	return state.MarshalText(r.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (r *WELL1024a) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("well1024a", text)
+	if err != nil {
+		return err
+	}
+	return r.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (r *WELL1024a) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("well1024a", r.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (r *WELL1024a) UnmarshalJSON(data []byte) error {
+	return r.setState(state.NewJSONDecoder("well1024a", data))
+}
@@ -0,0 +1,195 @@
+package well
+
+import (
+	"encoding/gob"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+)
+
+var (
+	well19937c *WELL19937c
+	_          prng.Engine        = well19937c
+	_          prng.StateRestorer = well19937c
+)
+
+func init() {
+	prng.Register("well19937c", func(seed uint64) prng.Engine { return New19937c(seed) })
+	gob.Register(new(WELL19937c))
+}
+
+// Parameters of WELL19937
+const (
+	r19937 = 624
+	m1     = 70
+	m2     = 179
+	m3     = 449
+	maskU  = 0x7FFFFFFF // 31 least significant bits
+	maskL  = ^uint32(maskU)
+)
+
+// WELL19937c implements the WELL19937c PRNG with 19937 bits of state and
+// period 2^19937-1, i.e. WELL19937a followed by a Matsumoto-Kurita
+// tempering of its output that makes it maximally equidistributed
+type WELL19937c struct {
+	seed  uint64
+	index int
+	state [r19937]uint32
+}
+
+// New19937c returns a new instance of the WELL19937c PRNG Engine.
+// If the seed provided is 0, the engine is initialized with current time
+func New19937c(seed uint64) *WELL19937c {
+	r := new(WELL19937c)
+	r.Seed(seed)
+	return r
+}
+
+// Uint32 returns a pseudo-random 32-bit value in [0, 2^32) as a uint32,
+// i.e. the output of WELLRNG19937c of the reference implementation before
+// its conversion to a float64.
+// Uint32 advances the internal state of the engine.
+func (r *WELL19937c) Uint32() uint32 {
+	s, i := &r.state, r.index
+	// at returns the word at index i+k modulo r19937
+	at := func(k int) int {
+		if j := i + k; j < r19937 {
+			return j
+		}
+		return i + k - r19937
+	}
+	z0 := (s[at(r19937-1)] & maskL) | (s[at(r19937-2)] & maskU)
+	v0, vm1, vm2, vm3 := s[i], s[at(m1)], s[at(m2)], s[at(m3)]
+	z1 := v0 ^ (v0 << 25) ^ vm1 ^ (vm1 >> 27)
+	z2 := (vm2 >> 9) ^ vm3 ^ (vm3 >> 1)
+	v1 := z1 ^ z2
+	s[i] = v1
+	r.index = at(r19937 - 1)
+	s[r.index] = z0 ^ z1 ^ (z1 << 9) ^ z2 ^ (z2 << 21) ^ v1 ^ (v1 >> 21)
+
+	// Tempering
+	y := s[r.index]
+	y ^= (y << 7) & 0xE46E1700
+	y ^= (y << 15) & 0x9B868000
+	return y
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64,
+// made of two consecutive values of Uint32, the first one being the most
+// significant.
+// Uint64 advances the internal state of the engine.
+func (r *WELL19937c) Uint64() uint64 {
+	hi := uint64(r.Uint32())
+	return hi<<32 | uint64(r.Uint32())
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (r *WELL19937c) Float64() float64 {
+	return float64(r.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (r *WELL19937c) Float64OO() float64 {
+	return (float64(r.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine
+func (r *WELL19937c) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	r.seed = seed
+	r.index = 0
+	seedState(r.state[:], seed)
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (r *WELL19937c) GetSeed() uint64 { return r.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (r *WELL19937c) GetState() []byte {
+	return state.Encode("well19937c", r.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (r *WELL19937c) fields() []interface{} {
+	return stateFields(r.seed, r.index, r.state[:])
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (r *WELL19937c) SetState(b []byte) {
+	if err := r.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of WELL19937c, e.g. if it is truncated, if its
+// index is out of range or if its state is all zero
+func (r *WELL19937c) SetStateE(b []byte) error {
+	return r.setState(state.NewDecoder("well19937c", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (r *WELL19937c) setState(d *state.Decoder) error {
+	seed, index, err := decodeState(d, r.state[:])
+	if err != nil {
+		return err
+	}
+	r.seed, r.index = seed, index
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed
+func (r *WELL19937c) Reset() {
+	r.Seed(r.seed)
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (r *WELL19937c) MarshalBinary() ([]byte, error) { return r.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (r *WELL19937c) UnmarshalBinary(b []byte) error { return r.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (r *WELL19937c) MarshalText() ([]byte, error) {
+	return state.MarshalText(r.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (r *WELL19937c) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("well19937c", text)
+	if err != nil {
+		return err
+	}
+	return r.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (r *WELL19937c) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("well19937c", r.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (r *WELL19937c) UnmarshalJSON(data []byte) error {
+	return r.setState(state.NewJSONDecoder("well19937c", data))
+}
@@ -0,0 +1,212 @@
+package well_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/well"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadir19937c = filepath.Join("..", "..", "data", "well19937c")
+
+func Test_WELL19937c_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := well.New19937c(0)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+}
+
+func Test_WELL19937c_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed remains same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := well.New19937c(seed)
+		states[i] = r.GetState()
+	}
+	for i, state := range states {
+		r := well.New19937c(0)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+	}
+
+	// Checking that the streams remain same after getting and setting states
+	r1 := well.New19937c(0)
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint32()
+	}
+	r2 := well.New19937c(0)
+	r2.SetState(r1.GetState())
+	for i := 0; i < 624; i++ {
+		_ = r1.Uint32()
+		_ = r2.Uint32()
+	}
+	assert.Equal(r1.Uint32(), r2.Uint32())
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	assert.Equal(r1.Float64(), r2.Float64())
+	assert.Equal(r1.Float64OO(), r2.Float64OO())
+	r1.Reset()
+	r2.Reset()
+	assert.Equal(r1.Uint64(), r2.Uint64())
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := well.New19937c(0)
+		r1.SetState([]byte("Hello"))
+	})
+	assert.Panics(func() {
+		r1 := well.New19937c(0)
+		r1.SetState(nil)
+	})
+	assert.Panics(func() {
+		r1 := well.New19937c(0)
+		r1.SetState(well.New512a(1).GetState())
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("well19937c"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(0))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := well.New19937c(0)
+		r1.SetState(buf.Bytes())
+	})
+	// Invalid index
+	state := [624]uint32{1}
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("well19937c"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(624))
+	_ = binary.Write(buf, binary.LittleEndian, state)
+	assert.Panics(func() {
+		r1 := well.New19937c(0)
+		r1.SetState(buf.Bytes())
+	})
+	// The state must not be all zero
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("well19937c"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(0))
+	_ = binary.Write(buf, binary.LittleEndian, [624]uint32{})
+	assert.Panics(func() {
+		r1 := well.New19937c(0)
+		r1.SetState(buf.Bytes())
+	})
+}
+
+func Test_WELL19937c_Uint32(t *testing.T) {
+	e := well.New19937c(0)
+	filenames := prngtest.GetDataFiles(datadir19937c, "well19937c-*-uint32-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_WELL19937c_Uint64(t *testing.T) {
+	e := well.New19937c(0)
+	filenames := prngtest.GetDataFiles(datadir19937c, "well19937c-*-uint64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_WELL19937c_Float64(t *testing.T) {
+	e := well.New19937c(0)
+	filenames := prngtest.GetDataFiles(datadir19937c, "well19937c-*-float64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_WELL19937c_Float64OO(t *testing.T) {
+	e := well.New19937c(0)
+	filenames := prngtest.GetDataFiles(datadir19937c, "well19937c-*-float64oo-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_WELL19937c_Reference(t *testing.T) {
+	assert := assert.New(t)
+
+	// First outputs of WELLRNG19937c of the reference implementation,
+	// multiplied by 2^32, after InitWELLRNG19937c with init[j] = 69069*j + 1
+	expected := []uint32{722440716, 2828428179, 4005177229, 4105963168,
+		1249584981, 1405804704, 3753932383, 2517135746, 597935601,
+		1628625873}
+	var init [624]uint32
+	for j := range init {
+		init[j] = 69069*uint32(j) + 1
+	}
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("well19937c"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(0))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(0))
+	_ = binary.Write(buf, binary.LittleEndian, init)
+	r := well.New19937c(0)
+	r.SetState(buf.Bytes())
+	for _, v := range expected {
+		assert.Equal(v, r.Uint32())
+	}
+
+	// The 2000th output, after more than three turns of the state through
+	// all the cases of WELLRNG19937c
+	for i := 10; i < 1999; i++ {
+		_ = r.Uint32()
+	}
+	assert.Equal(uint32(1062332671), r.Uint32())
+}
+
+// Benchmarks
+func Benchmark_WELL19937c_Uint32(b *testing.B) {
+	rng := well.New19937c(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint32()
+	}
+}
+
+func Benchmark_WELL19937c_Uint64(b *testing.B) {
+	rng := well.New19937c(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_WELL19937c_Float64(b *testing.B) {
+	rng := well.New19937c(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_WELL19937c_Float64OO(b *testing.B) {
+	rng := well.New19937c(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+// Example - WELL19937c Usage
+func ExampleWELL19937c() {
+	// Create a new instance of the WELL19937c engine
+	r := well.New19937c(20170612)
+
+	fmt.Println("WELL19937c: seed = 20170612; Uint32()")
+	for i := 0; i < 3; i++ {
+		// Draw 32 random bits as a uint32
+		fmt.Println(r.Uint32())
+	}
+
+	// Output:
+	// WELL19937c: seed = 20170612; Uint32()
+	// 838516347
+	// 1375053254
+	// 2551199245
+}
@@ -0,0 +1,173 @@
+package well
+
+import (
+	"encoding/gob"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+)
+
+var (
+	well512a *WELL512a
+	_        prng.Engine        = well512a
+	_        prng.StateRestorer = well512a
+)
+
+func init() {
+	prng.Register("well512a", func(seed uint64) prng.Engine { return New512a(seed) })
+	gob.Register(new(WELL512a))
+}
+
+// WELL512a implements the WELL512a PRNG with 512 bits of state and period
+// 2^512-1
+type WELL512a struct {
+	seed  uint64
+	index int
+	state [16]uint32
+}
+
+// New512a returns a new instance of the WELL512a PRNG Engine.
+// If the seed provided is 0, the engine is initialized with current time
+func New512a(seed uint64) *WELL512a {
+	r := new(WELL512a)
+	r.Seed(seed)
+	return r
+}
+
+// Uint32 returns a pseudo-random 32-bit value in [0, 2^32) as a uint32,
+// i.e. the output of WELLRNG512a of the reference implementation before
+// its conversion to a float64.
+// Uint32 advances the internal state of the engine.
+func (r *WELL512a) Uint32() uint32 {
+	s, i := &r.state, r.index
+	z0 := s[(i+15)&15]
+	v0, vm1, vm2 := s[i], s[(i+13)&15], s[(i+9)&15]
+	z1 := v0 ^ (v0 << 16) ^ vm1 ^ (vm1 << 15)
+	z2 := vm2 ^ (vm2 >> 11)
+	v1 := z1 ^ z2
+	s[i] = v1
+	r.index = (i + 15) & 15
+	s[r.index] = z0 ^ (z0 << 2) ^ z1 ^ (z1 << 18) ^ (z2 << 28) ^
+		v1 ^ ((v1 << 5) & 0xDA442D24)
+	return s[r.index]
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64,
+// made of two consecutive values of Uint32, the first one being the most
+// significant.
+// Uint64 advances the internal state of the engine.
+func (r *WELL512a) Uint64() uint64 {
+	hi := uint64(r.Uint32())
+	return hi<<32 | uint64(r.Uint32())
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (r *WELL512a) Float64() float64 {
+	return float64(r.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (r *WELL512a) Float64OO() float64 {
+	return (float64(r.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine
+func (r *WELL512a) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	r.seed = seed
+	r.index = 0
+	seedState(r.state[:], seed)
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (r *WELL512a) GetSeed() uint64 { return r.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (r *WELL512a) GetState() []byte {
+	return state.Encode("well512a", r.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (r *WELL512a) fields() []interface{} {
+	return stateFields(r.seed, r.index, r.state[:])
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (r *WELL512a) SetState(b []byte) {
+	if err := r.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of WELL512a, e.g. if it is truncated, if its
+// index is out of range or if its state is all zero
+func (r *WELL512a) SetStateE(b []byte) error {
+	return r.setState(state.NewDecoder("well512a", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (r *WELL512a) setState(d *state.Decoder) error {
+	seed, index, err := decodeState(d, r.state[:])
+	if err != nil {
+		return err
+	}
+	r.seed, r.index = seed, index
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed
+func (r *WELL512a) Reset() {
+	r.Seed(r.seed)
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (r *WELL512a) MarshalBinary() ([]byte, error) { return r.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (r *WELL512a) UnmarshalBinary(b []byte) error { return r.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (r *WELL512a) MarshalText() ([]byte, error) {
+	return state.MarshalText(r.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (r *WELL512a) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("well512a", text)
+	if err != nil {
+		return err
+	}
+	return r.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (r *WELL512a) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("well512a", r.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (r *WELL512a) UnmarshalJSON(data []byte) error {
+	return r.setState(state.NewJSONDecoder("well512a", data))
+}
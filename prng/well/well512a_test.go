@@ -0,0 +1,213 @@
+package well_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/well"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadir512a = filepath.Join("..", "..", "data", "well512a")
+var longTest bool
+
+// TestMain is the entry point for testing
+func TestMain(m *testing.M) {
+	longTest = prngtest.ParseCommandLine()
+	os.Exit(m.Run())
+}
+
+func Test_WELL512a_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := well.New512a(0)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+}
+
+func Test_WELL512a_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed remains same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := well.New512a(seed)
+		states[i] = r.GetState()
+	}
+	for i, state := range states {
+		r := well.New512a(0)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+	}
+
+	// Checking that the streams remain same after getting and setting states
+	r1 := well.New512a(0)
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint32()
+	}
+	r2 := well.New512a(0)
+	r2.SetState(r1.GetState())
+	for i := 0; i < 16; i++ {
+		_ = r1.Uint32()
+		_ = r2.Uint32()
+	}
+	assert.Equal(r1.Uint32(), r2.Uint32())
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	assert.Equal(r1.Float64(), r2.Float64())
+	assert.Equal(r1.Float64OO(), r2.Float64OO())
+	r1.Reset()
+	r2.Reset()
+	assert.Equal(r1.Uint64(), r2.Uint64())
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := well.New512a(0)
+		r1.SetState([]byte("Hello"))
+	})
+	assert.Panics(func() {
+		r1 := well.New512a(0)
+		r1.SetState(nil)
+	})
+	assert.Panics(func() {
+		r1 := well.New512a(0)
+		r1.SetState(well.New1024a(1).GetState())
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("well512a"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(0))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := well.New512a(0)
+		r1.SetState(buf.Bytes())
+	})
+	// Invalid index
+	state := [16]uint32{1}
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("well512a"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(16))
+	_ = binary.Write(buf, binary.LittleEndian, state)
+	assert.Panics(func() {
+		r1 := well.New512a(0)
+		r1.SetState(buf.Bytes())
+	})
+	// The state must not be all zero
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("well512a"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(0))
+	_ = binary.Write(buf, binary.LittleEndian, [16]uint32{})
+	assert.Panics(func() {
+		r1 := well.New512a(0)
+		r1.SetState(buf.Bytes())
+	})
+}
+
+func Test_WELL512a_Uint32(t *testing.T) {
+	e := well.New512a(0)
+	filenames := prngtest.GetDataFiles(datadir512a, "well512a-*-uint32-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_WELL512a_Uint64(t *testing.T) {
+	e := well.New512a(0)
+	filenames := prngtest.GetDataFiles(datadir512a, "well512a-*-uint64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_WELL512a_Float64(t *testing.T) {
+	e := well.New512a(0)
+	filenames := prngtest.GetDataFiles(datadir512a, "well512a-*-float64-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_WELL512a_Float64OO(t *testing.T) {
+	e := well.New512a(0)
+	filenames := prngtest.GetDataFiles(datadir512a, "well512a-*-float64oo-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_WELL512a_Reference(t *testing.T) {
+	assert := assert.New(t)
+
+	// First outputs of WELLRNG512a of the reference implementation,
+	// multiplied by 2^32, after InitWELLRNG512a with init[j] = 69069*j + 1
+	expected := []uint32{1335198221, 339584655, 3472898962, 2218279404,
+		252477754, 2368248252, 2934939843, 2153767957, 2885935619,
+		3211315384}
+	var init [16]uint32
+	for j := range init {
+		init[j] = 69069*uint32(j) + 1
+	}
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("well512a"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(0))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(0))
+	_ = binary.Write(buf, binary.LittleEndian, init)
+	r := well.New512a(0)
+	r.SetState(buf.Bytes())
+	for _, v := range expected {
+		assert.Equal(v, r.Uint32())
+	}
+}
+
+// Benchmarks
+func Benchmark_WELL512a_Uint32(b *testing.B) {
+	rng := well.New512a(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint32()
+	}
+}
+
+func Benchmark_WELL512a_Uint64(b *testing.B) {
+	rng := well.New512a(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_WELL512a_Float64(b *testing.B) {
+	rng := well.New512a(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_WELL512a_Float64OO(b *testing.B) {
+	rng := well.New512a(0)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+// Example - WELL512a Usage
+func ExampleWELL512a() {
+	// Create a new instance of the WELL512a engine
+	r := well.New512a(20170612)
+
+	fmt.Println("WELL512a: seed = 20170612; Uint32()")
+	for i := 0; i < 3; i++ {
+		// Draw 32 random bits as a uint32
+		fmt.Println(r.Uint32())
+	}
+
+	// Output:
+	// WELL512a: seed = 20170612; Uint32()
+	// 2823681311
+	// 2948545582
+	// 4070818388
+}
@@ -0,0 +1,15 @@
+// Package wyrand implements the wyrand pseudo-random number generator of
+// Wang Yi, from the wyhash project.
+//
+// wyrand adds a constant to its 64-bit state and returns the xor of the
+// two halves of a 64x64->128-bit multiplication (math/bits.Mul64) of the
+// new state. It is one of the fastest generators passing BigCrush and
+// PractRand, and is used e.g. by the Go runtime and by Zig. As the state is
+// a Weyl sequence, the period is 2^64 and Advance (prng.Advancer) takes
+// constant time. Not every 64-bit value is generated over a period, and
+// some are generated more than once.
+//
+// References:
+//
+// https://github.com/wangyi-fudan/wyhash
+package wyrand
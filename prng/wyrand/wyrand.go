@@ -0,0 +1,175 @@
+package wyrand
+
+import (
+	"encoding/gob"
+	"math/bits"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+)
+
+var (
+	wyrand *Wyrand
+	_      prng.Engine        = wyrand
+	_      prng.Advancer      = wyrand
+	_      prng.StateRestorer = wyrand
+)
+
+func init() {
+	prng.Register("wyrand", func(seed uint64) prng.Engine { return New(seed) })
+	gob.Register(new(Wyrand))
+}
+
+// Constants of wyrand: the increment of the Weyl sequence and the value
+// mixed with the state before the multiplication
+const (
+	increment uint64 = 0xA0761D6478BD642F
+	mixer     uint64 = 0xE7037ED1A0B428DB
+)
+
+// Wyrand implements the wyrand PRNG of Wang Yi: a Weyl sequence whose
+// output is the xor of the two halves of the 128-bit product of the state
+// and the state xored with a constant. Its period is 2^64.
+type Wyrand struct {
+	seed  uint64
+	state uint64
+}
+
+// New returns a new instance of the Wyrand PRNG Engine.
+// If the seed provided is 0, the engine is initialized with current time
+func New(seed uint64) *Wyrand {
+	r := new(Wyrand)
+	r.Seed(seed)
+	return r
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64.
+// Uint64 advances the internal state of the engine.
+func (r *Wyrand) Uint64() uint64 {
+	r.state += increment
+	hi, lo := bits.Mul64(r.state, r.state^mixer)
+	return hi ^ lo
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (r *Wyrand) Float64() float64 {
+	return float64(r.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (r *Wyrand) Float64OO() float64 {
+	return (float64(r.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine. The seed is the
+// initial state, as for wyrand of the reference implementation.
+// If the seed provided is 0, the engine is initialized with current time
+func (r *Wyrand) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	r.seed = seed
+	r.state = seed
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (r *Wyrand) GetSeed() uint64 { return r.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (r *Wyrand) GetState() []byte {
+	return state.Encode("wyrand", r.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (r *Wyrand) fields() []interface{} {
+	return []interface{}{
+		uint64(r.seed),
+		uint64(r.state),
+	}
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (r *Wyrand) SetState(b []byte) {
+	if err := r.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of Wyrand, e.g. if it is truncated
+func (r *Wyrand) SetStateE(b []byte) error {
+	return r.setState(state.NewDecoder("wyrand", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (r *Wyrand) setState(d *state.Decoder) error {
+	var seed, st uint64
+	d.Read(&seed, &st)
+	if err := d.Err(); err != nil {
+		return err
+	}
+	r.seed = seed
+	r.state = st
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed
+func (r *Wyrand) Reset() {
+	r.state = r.seed
+}
+
+// Advance advances the internal state of the engine by n steps, i.e. it is
+// equivalent to calling Uint64 n times. Since the state of the engine is a
+// Weyl sequence, Advance takes constant time.
+func (r *Wyrand) Advance(n uint64) {
+	r.state += n * increment
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (r *Wyrand) MarshalBinary() ([]byte, error) { return r.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (r *Wyrand) UnmarshalBinary(b []byte) error { return r.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (r *Wyrand) MarshalText() ([]byte, error) {
+	return state.MarshalText(r.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (r *Wyrand) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("wyrand", text)
+	if err != nil {
+		return err
+	}
+	return r.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (r *Wyrand) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("wyrand", r.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (r *Wyrand) UnmarshalJSON(data []byte) error {
+	return r.setState(state.NewJSONDecoder("wyrand", data))
+}
@@ -1,8 +1,29 @@
 // Package xoroshiro128plus provides implementation for xoroshiro PRNG
-// algorithm
+// algorithm with 128 bits of state and three scramblers:
+//
+//   - Xoroshiro128Plus (xoroshiro128+) uses the original rotation constants
+//     55/14/36 and the sum of the state words as output. The lowest bit of its
+//     output is an LFSR of degree 128 and the second lowest bit fails
+//     linearity tests in the long run, e.g. Uint64() % n for even n inherits
+//     this weakness. Use the upper bits, as Float64 and Float64OO do.
+//   - Xoroshiro128StarStar (xoroshiro128**) uses the rotation constants
+//     24/16/37. All 64 bits of its output are safe to use.
+//   - Xoroshiro128PlusPlus (xoroshiro128++) uses the rotation constants
+//     49/21/28. All 64 bits of its output are safe to use.
+//
+// All three engines implement prng.LongJumper: Jump advances the engine by
+// 2^64 steps and LongJump by 2^96 steps. The jump polynomials are the ones
+// published with the reference implementations, except the long-jump
+// polynomial of Xoroshiro128Plus, which is x^(2^96) modulo the
+// characteristic polynomial of its linear engine.
 //
 // References:
 //
+// D. Blackman and S. Vigna, "Scrambled Linear Pseudorandom Number
+// Generators", ACM Transactions on Mathematical Software 47(4) (2021).
+//
 // http://xoroshiro.di.unimi.it/
 // http://xoroshiro.di.unimi.it/xoroshiro128plus.c
+// http://prng.di.unimi.it/xoroshiro128starstar.c
+// http://prng.di.unimi.it/xoroshiro128plusplus.c
 package xoroshiro128plus
@@ -0,0 +1,46 @@
+package xoroshiro128plus
+
+import (
+	"fmt"
+
+	"github.com/shivakar/random/prng/internal/state"
+)
+
+// stateFields returns the fields of the state of a xoroshiro128 engine: the
+// seed and the two state words
+func stateFields(seed uint64, st [2]uint64) []interface{} {
+	return []interface{}{
+		uint64(seed),
+		uint64(st[0]),
+		uint64(st[1]),
+	}
+}
+
+// decodeState returns the seed and the state words of stateFields decoded by
+// d, or an error if the state is invalid or if its state words are all zero
+func decodeState(d *state.Decoder) (uint64, [2]uint64, error) {
+	var seed uint64
+	var st [2]uint64
+	d.Read(&seed, &st)
+	if st == [2]uint64{} {
+		d.Fail(fmt.Errorf("State must not be all zero"))
+	}
+	return seed, st, d.Err()
+}
+
+// jump sets state to poly(T)*state, where T is the transition function
+// implemented by next
+func jump(state *[2]uint64, poly [2]uint64, next func()) {
+	var s0, s1 uint64
+	for _, p := range poly {
+		for b := uint(0); b < 64; b++ {
+			if p&(uint64(1)<<b) != 0 {
+				s0 ^= state[0]
+				s1 ^= state[1]
+			}
+			next()
+		}
+	}
+	state[0] = s0
+	state[1] = s1
+}
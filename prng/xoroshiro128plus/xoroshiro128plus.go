@@ -1,19 +1,32 @@
 package xoroshiro128plus
 
 import (
-	"bytes"
-	"encoding/binary"
-	"fmt"
-	"strings"
+	"encoding/gob"
 	"time"
 
 	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
 	"github.com/shivakar/random/prng/splitmix64"
 )
 
 var (
 	xoroshiro128plus *Xoroshiro128Plus
-	_                prng.Engine = xoroshiro128plus
+	_                prng.Engine        = xoroshiro128plus
+	_                prng.LongJumper    = xoroshiro128plus
+	_                prng.Splitter      = xoroshiro128plus
+	_                prng.StateRestorer = xoroshiro128plus
+)
+
+func init() {
+	prng.Register("xoroshiro128plus", func(seed uint64) prng.Engine { return New(seed) })
+	gob.Register(new(Xoroshiro128Plus))
+}
+
+// Jump polynomials, i.e. x^(2^64) and x^(2^96) modulo the characteristic
+// polynomial of the linear engine, one coefficient per bit
+var (
+	jumpPoly     = [2]uint64{0xbeac0467eba5facb, 0xd86b048b86aa9922}
+	longJumpPoly = [2]uint64{0x18f7c399ccebda8d, 0xf2deac28bef3bb07}
 )
 
 /*
@@ -32,7 +45,8 @@ func rotl36(x uint64) uint64 {
 
 // Xoroshiro128Plus implements a xoroshiro PRNG with 128 bits of state and
 // a maximal period of 2^128-1. The algorithm uses addition as the non-linear
-// transformation function
+// transformation function. The two lowest bits of its output have low linear
+// complexity.
 type Xoroshiro128Plus struct {
 	seed  uint64
 	state [2]uint64
@@ -93,46 +107,63 @@ func (x *Xoroshiro128Plus) GetSeed() uint64 { return x.seed }
 // GetState returns the internal state of the engine as []byte
 // GetState can be used to save the state, e.g. to a file
 func (x *Xoroshiro128Plus) GetState() []byte {
-	const msg = "xoroshiro128plus: Error encoding state"
-	buf := new(bytes.Buffer)
-	data := []interface{}{
-		[]byte("xoroshiro128plus"),
-		uint64(x.seed),
-		uint64(x.state[0]),
-		uint64(x.state[1]),
-	}
-	for _, v := range data {
-		if err := binary.Write(buf, binary.LittleEndian, v); err != nil {
-			panic(strings.Join([]string{msg, err.Error()}, "\n"))
-		}
-	}
-	return buf.Bytes()
+	return state.Encode("xoroshiro128plus", x.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (x *Xoroshiro128Plus) fields() []interface{} {
+	return stateFields(x.seed, x.state)
 }
 
 // SetState sets the internal state of the engine from a []byte
 // SetState can be used to resume from a saved state
 func (x *Xoroshiro128Plus) SetState(b []byte) {
-	const msg = "xoroshiro128plus: Error decoding state"
-	const algo = "xoroshiro128plus"
-	buf := bytes.NewReader(b)
-	nb := make([]byte, len(algo))
-	_, err := buf.Read(nb)
-	if err != nil {
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+	if err := x.SetStateE(b); err != nil {
+		panic(err.Error())
 	}
-	if string(nb) != algo {
-		err = fmt.Errorf("Expected '%s', got '%s'", algo, string(nb))
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
-	}
-	if err = binary.Read(buf, binary.LittleEndian, &x.seed); err != nil {
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
-	}
-	if err = binary.Read(buf, binary.LittleEndian, &x.state[0]); err != nil {
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of Xoroshiro128Plus, e.g. if it is truncated or if its
+// state is all zero
+func (x *Xoroshiro128Plus) SetStateE(b []byte) error {
+	return x.setState(state.NewDecoder("xoroshiro128plus", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (x *Xoroshiro128Plus) setState(d *state.Decoder) error {
+	seed, st, err := decodeState(d)
+	if err != nil {
+		return err
 	}
-	if err = binary.Read(buf, binary.LittleEndian, &x.state[1]); err != nil {
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+	x.seed, x.state = seed, st
+	return nil
+}
+
+// Jump advances the internal state of the engine by 2^64 steps.
+// It can be used to generate 2^64 non-overlapping sub-sequences for
+// parallel computations.
+func (x *Xoroshiro128Plus) Jump() {
+	jump(&x.state, jumpPoly, func() { _ = x.Uint64() })
+}
+
+// LongJump advances the internal state of the engine by 2^96 steps.
+// It can be used to generate 2^32 starting points, from each of which Jump
+// generates 2^32 non-overlapping sub-sequences for distributed computations.
+func (x *Xoroshiro128Plus) LongJump() {
+	jump(&x.state, longJumpPoly, func() { _ = x.Uint64() })
+}
+
+// Split returns a new Xoroshiro128Plus engine seeded, through SplitMix64 as in
+// Seed, with the next non-zero output of this engine.
+// Split advances the internal state of the engine.
+func (x *Xoroshiro128Plus) Split() prng.Engine {
+	seed := x.Uint64()
+	for seed == 0 {
+		seed = x.Uint64()
 	}
+	return New(seed)
 }
 
 // Reset reverts the internal state of the engine to its default state,
@@ -140,3 +171,41 @@ func (x *Xoroshiro128Plus) SetState(b []byte) {
 func (x *Xoroshiro128Plus) Reset() {
 	x.Seed(x.seed)
 }
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (x *Xoroshiro128Plus) MarshalBinary() ([]byte, error) { return x.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (x *Xoroshiro128Plus) UnmarshalBinary(b []byte) error { return x.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (x *Xoroshiro128Plus) MarshalText() ([]byte, error) {
+	return state.MarshalText(x.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (x *Xoroshiro128Plus) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("xoroshiro128plus", text)
+	if err != nil {
+		return err
+	}
+	return x.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (x *Xoroshiro128Plus) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("xoroshiro128plus", x.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (x *Xoroshiro128Plus) UnmarshalJSON(data []byte) error {
+	return x.setState(state.NewJSONDecoder("xoroshiro128plus", data))
+}
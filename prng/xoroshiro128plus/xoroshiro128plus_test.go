@@ -76,6 +76,10 @@ func Test_xoroshiro128Plus_GetSetState(t *testing.T) {
 		r1 := xoroshiro128plus.New(0)
 		r1.SetState(nil)
 	})
+	assert.Panics(func() {
+		r1 := xoroshiro128plus.New(0)
+		r1.SetState(xoroshiro128plus.NewPlusPlus(1).GetState())
+	})
 
 	buf := new(bytes.Buffer)
 	_ = binary.Write(buf, binary.LittleEndian, []byte("xoroshiro128plust"))
@@ -120,6 +124,22 @@ func Test_xoroshiro128Plus_Float64OO(t *testing.T) {
 	prngtest.CompareDraws(t, e, filenames, longTest)
 }
 
+func Test_xoroshiro128Plus_Jump(t *testing.T) {
+	e := xoroshiro128plus.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "*-jump-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_xoroshiro128Plus_LongJump(t *testing.T) {
+	e := xoroshiro128plus.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "*-longjump-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_xoroshiro128Plus_Split(t *testing.T) {
+	prngtest.CheckSplit(t, xoroshiro128plus.New(20170612))
+}
+
 // Benchmarks
 func Benchmark_xoroshiro128Plus_Uint64(b *testing.B) {
 	rng := xoroshiro128plus.New(0)
@@ -143,7 +163,7 @@ func Benchmark_xoroshiro128Plus_Float64OO(b *testing.B) {
 }
 
 // Example - Xoroshiro128+ Usage
-func ExampleXoroshiro128plus() {
+func ExampleXoroshiro128Plus() {
 	// Create a new instance of Xoroshiro128+ engine
 	r := xoroshiro128plus.New(20170612)
 
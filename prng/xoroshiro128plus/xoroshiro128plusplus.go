@@ -0,0 +1,197 @@
+package xoroshiro128plus
+
+import (
+	"encoding/gob"
+	"math/bits"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+var (
+	xoroshiro128plusplus *Xoroshiro128PlusPlus
+	_                    prng.Engine        = xoroshiro128plusplus
+	_                    prng.LongJumper    = xoroshiro128plusplus
+	_                    prng.Splitter      = xoroshiro128plusplus
+	_                    prng.StateRestorer = xoroshiro128plusplus
+)
+
+func init() {
+	prng.Register("xoroshiro128plusplus", func(seed uint64) prng.Engine { return NewPlusPlus(seed) })
+	gob.Register(new(Xoroshiro128PlusPlus))
+}
+
+// Jump polynomials of the linear engine with rotation constants 49/21/28
+var (
+	jumpPoly49     = [2]uint64{0x2bd7a6a6e99c2ddc, 0x0992ccaf6a6fca05}
+	longJumpPoly49 = [2]uint64{0x360fd5f2cf8d5d99, 0x9c6e6877736c46e3}
+)
+
+// Xoroshiro128PlusPlus implements a xoroshiro PRNG with 128 bits of state
+// and a maximal period of 2^128-1, using the rotation constants 49/21/28 and
+// the rotated sum of the state words plus the first word as the non-linear
+// transformation function. All bits of its output are safe to use.
+type Xoroshiro128PlusPlus struct {
+	seed  uint64
+	state [2]uint64
+}
+
+// NewPlusPlus returns a new instance of the Xoroshiro128PlusPlus PRNG Engine.
+// If the seed provided is 0, the engine is initialized with current time
+func NewPlusPlus(seed uint64) *Xoroshiro128PlusPlus {
+	r := new(Xoroshiro128PlusPlus)
+	r.Seed(seed)
+	return r
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64.
+// Uint64 advances the internal state of the engine.
+func (x *Xoroshiro128PlusPlus) Uint64() uint64 {
+	s0 := x.state[0]
+	s1 := x.state[1]
+	result := bits.RotateLeft64(s0+s1, 17) + s0
+
+	s1 ^= s0
+	x.state[0] = bits.RotateLeft64(s0, 49) ^ s1 ^ (s1 << 21)
+	x.state[1] = bits.RotateLeft64(s1, 28)
+
+	return result
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (x *Xoroshiro128PlusPlus) Float64() float64 {
+	return float64(x.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (x *Xoroshiro128PlusPlus) Float64OO() float64 {
+	return (float64(x.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine
+func (x *Xoroshiro128PlusPlus) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	x.seed = seed
+	ms := splitmix64.New(seed)
+	x.state[0] = ms.Uint64()
+	x.state[1] = ms.Uint64()
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (x *Xoroshiro128PlusPlus) GetSeed() uint64 { return x.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (x *Xoroshiro128PlusPlus) GetState() []byte {
+	return state.Encode("xoroshiro128plusplus", x.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (x *Xoroshiro128PlusPlus) fields() []interface{} {
+	return stateFields(x.seed, x.state)
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (x *Xoroshiro128PlusPlus) SetState(b []byte) {
+	if err := x.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of Xoroshiro128PlusPlus, e.g. if it is truncated or if its
+// state is all zero
+func (x *Xoroshiro128PlusPlus) SetStateE(b []byte) error {
+	return x.setState(state.NewDecoder("xoroshiro128plusplus", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (x *Xoroshiro128PlusPlus) setState(d *state.Decoder) error {
+	seed, st, err := decodeState(d)
+	if err != nil {
+		return err
+	}
+	x.seed, x.state = seed, st
+	return nil
+}
+
+// Jump advances the internal state of the engine by 2^64 steps.
+// It can be used to generate 2^64 non-overlapping sub-sequences for
+// parallel computations.
+func (x *Xoroshiro128PlusPlus) Jump() {
+	jump(&x.state, jumpPoly49, func() { _ = x.Uint64() })
+}
+
+// LongJump advances the internal state of the engine by 2^96 steps.
+// It can be used to generate 2^32 starting points, from each of which Jump
+// generates 2^32 non-overlapping sub-sequences for distributed computations.
+func (x *Xoroshiro128PlusPlus) LongJump() {
+	jump(&x.state, longJumpPoly49, func() { _ = x.Uint64() })
+}
+
+// Split returns a new Xoroshiro128PlusPlus engine seeded, through SplitMix64
+// as in Seed, with the next non-zero output of this engine.
+// Split advances the internal state of the engine.
+func (x *Xoroshiro128PlusPlus) Split() prng.Engine {
+	seed := x.Uint64()
+	for seed == 0 {
+		seed = x.Uint64()
+	}
+	return NewPlusPlus(seed)
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed
+func (x *Xoroshiro128PlusPlus) Reset() {
+	x.Seed(x.seed)
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (x *Xoroshiro128PlusPlus) MarshalBinary() ([]byte, error) { return x.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (x *Xoroshiro128PlusPlus) UnmarshalBinary(b []byte) error { return x.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (x *Xoroshiro128PlusPlus) MarshalText() ([]byte, error) {
+	return state.MarshalText(x.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (x *Xoroshiro128PlusPlus) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("xoroshiro128plusplus", text)
+	if err != nil {
+		return err
+	}
+	return x.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (x *Xoroshiro128PlusPlus) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("xoroshiro128plusplus", x.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (x *Xoroshiro128PlusPlus) UnmarshalJSON(data []byte) error {
+	return x.setState(state.NewJSONDecoder("xoroshiro128plusplus", data))
+}
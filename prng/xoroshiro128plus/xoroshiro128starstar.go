@@ -0,0 +1,197 @@
+package xoroshiro128plus
+
+import (
+	"encoding/gob"
+	"math/bits"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+var (
+	xoroshiro128starstar *Xoroshiro128StarStar
+	_                    prng.Engine        = xoroshiro128starstar
+	_                    prng.LongJumper    = xoroshiro128starstar
+	_                    prng.Splitter      = xoroshiro128starstar
+	_                    prng.StateRestorer = xoroshiro128starstar
+)
+
+func init() {
+	prng.Register("xoroshiro128starstar", func(seed uint64) prng.Engine { return NewStarStar(seed) })
+	gob.Register(new(Xoroshiro128StarStar))
+}
+
+// Jump polynomials of the linear engine with rotation constants 24/16/37
+var (
+	jumpPoly24     = [2]uint64{0xdf900294d8f554a5, 0x170865df4b3201fc}
+	longJumpPoly24 = [2]uint64{0xd2a98b26625eee7b, 0xdddf9b1090aa7ac1}
+)
+
+// Xoroshiro128StarStar implements a xoroshiro PRNG with 128 bits of state
+// and a maximal period of 2^128-1, using the rotation constants 24/16/37 and
+// multiplication by 5, a rotation and multiplication by 9 as the non-linear
+// transformation function. All bits of its output are safe to use.
+type Xoroshiro128StarStar struct {
+	seed  uint64
+	state [2]uint64
+}
+
+// NewStarStar returns a new instance of the Xoroshiro128StarStar PRNG Engine.
+// If the seed provided is 0, the engine is initialized with current time
+func NewStarStar(seed uint64) *Xoroshiro128StarStar {
+	r := new(Xoroshiro128StarStar)
+	r.Seed(seed)
+	return r
+}
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64.
+// Uint64 advances the internal state of the engine.
+func (x *Xoroshiro128StarStar) Uint64() uint64 {
+	s0 := x.state[0]
+	s1 := x.state[1]
+	result := bits.RotateLeft64(s0*5, 7) * 9
+
+	s1 ^= s0
+	x.state[0] = bits.RotateLeft64(s0, 24) ^ s1 ^ (s1 << 16)
+	x.state[1] = bits.RotateLeft64(s1, 37)
+
+	return result
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (x *Xoroshiro128StarStar) Float64() float64 {
+	return float64(x.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (x *Xoroshiro128StarStar) Float64OO() float64 {
+	return (float64(x.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine
+func (x *Xoroshiro128StarStar) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	x.seed = seed
+	ms := splitmix64.New(seed)
+	x.state[0] = ms.Uint64()
+	x.state[1] = ms.Uint64()
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (x *Xoroshiro128StarStar) GetSeed() uint64 { return x.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (x *Xoroshiro128StarStar) GetState() []byte {
+	return state.Encode("xoroshiro128starstar", x.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (x *Xoroshiro128StarStar) fields() []interface{} {
+	return stateFields(x.seed, x.state)
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (x *Xoroshiro128StarStar) SetState(b []byte) {
+	if err := x.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of Xoroshiro128StarStar, e.g. if it is truncated or if its
+// state is all zero
+func (x *Xoroshiro128StarStar) SetStateE(b []byte) error {
+	return x.setState(state.NewDecoder("xoroshiro128starstar", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (x *Xoroshiro128StarStar) setState(d *state.Decoder) error {
+	seed, st, err := decodeState(d)
+	if err != nil {
+		return err
+	}
+	x.seed, x.state = seed, st
+	return nil
+}
+
+// Jump advances the internal state of the engine by 2^64 steps.
+// It can be used to generate 2^64 non-overlapping sub-sequences for
+// parallel computations.
+func (x *Xoroshiro128StarStar) Jump() {
+	jump(&x.state, jumpPoly24, func() { _ = x.Uint64() })
+}
+
+// LongJump advances the internal state of the engine by 2^96 steps.
+// It can be used to generate 2^32 starting points, from each of which Jump
+// generates 2^32 non-overlapping sub-sequences for distributed computations.
+func (x *Xoroshiro128StarStar) LongJump() {
+	jump(&x.state, longJumpPoly24, func() { _ = x.Uint64() })
+}
+
+// Split returns a new Xoroshiro128StarStar engine seeded, through SplitMix64
+// as in Seed, with the next non-zero output of this engine.
+// Split advances the internal state of the engine.
+func (x *Xoroshiro128StarStar) Split() prng.Engine {
+	seed := x.Uint64()
+	for seed == 0 {
+		seed = x.Uint64()
+	}
+	return NewStarStar(seed)
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed
+func (x *Xoroshiro128StarStar) Reset() {
+	x.Seed(x.seed)
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (x *Xoroshiro128StarStar) MarshalBinary() ([]byte, error) { return x.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (x *Xoroshiro128StarStar) UnmarshalBinary(b []byte) error { return x.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (x *Xoroshiro128StarStar) MarshalText() ([]byte, error) {
+	return state.MarshalText(x.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (x *Xoroshiro128StarStar) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("xoroshiro128starstar", text)
+	if err != nil {
+		return err
+	}
+	return x.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (x *Xoroshiro128StarStar) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("xoroshiro128starstar", x.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (x *Xoroshiro128StarStar) UnmarshalJSON(data []byte) error {
+	return x.setState(state.NewJSONDecoder("xoroshiro128starstar", data))
+}
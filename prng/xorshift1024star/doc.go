@@ -1,6 +1,9 @@
 // Package xorshift1024star provides implementation for xorshift PRNG
 // algorithm with multiplication as the non-linear transformation function.
 //
+// Xorshift1024star implements prng.Jumper: Jump advances the engine by 2^512 steps
+// using the jump polynomial published with the reference implementation.
+//
 // References:
 //
 // http://xoroshiro.di.unimi.it/
@@ -1,21 +1,39 @@
 package xorshift1024star
 
 import (
-	"bytes"
-	"encoding/binary"
+	"encoding/gob"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
 	"github.com/shivakar/random/prng/splitmix64"
 )
 
 var (
 	xorshift1024star *Xorshift1024star
-	_                prng.Engine = xorshift1024star
+	_                prng.Engine        = xorshift1024star
+	_                prng.Jumper        = xorshift1024star
+	_                prng.Splitter      = xorshift1024star
+	_                prng.StateRestorer = xorshift1024star
 )
 
+func init() {
+	prng.Register("xorshift1024star", func(seed uint64) prng.Engine { return New(seed) })
+	gob.Register(new(Xorshift1024star))
+}
+
+// jumpPoly is x^(2^512) modulo the characteristic polynomial of the linear
+// engine, one coefficient per bit
+var jumpPoly = [16]uint64{
+	0x84242f96eca9c41d, 0xa3c65b8776f96855, 0x5b34a39f070b5837,
+	0x4489affce4f31a1e, 0x2ffeeb0a48316f40, 0xdc2d9891fe68c022,
+	0x3659132bb12fea70, 0xaac17d8efa43cab8, 0xc4cb815590989b13,
+	0x5ee975283d71c93b, 0x691548c86c1bd540, 0x7910c41d10a1e6a5,
+	0x0b5fc64563b3e2a8, 0x047f7684e9fc949d, 0xb99181f2d8f685ca,
+	0x284600e3f30e38c3,
+}
+
 // Xorshift1024star implements a Xorshift PRNG with 1024 bits of state and
 // a maximal period of 2^1024-1. The algorithm uses multiplication as the
 // non-linear transformation function
@@ -79,54 +97,83 @@ func (x *Xorshift1024star) GetSeed() uint64 { return x.seed }
 // GetState returns the internal state of the engine as []byte
 // GetState can be used to save the state, e.g. to a file
 func (x *Xorshift1024star) GetState() []byte {
-	const msg = "xorshift1024star: Error encoding state"
-	buf := new(bytes.Buffer)
-	data := []interface{}{
-		[]byte("xorshift1024star"),
+	return state.Encode("xorshift1024star", x.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (x *Xorshift1024star) fields() []interface{} {
+	return []interface{}{
 		uint64(x.seed),
 		uint64(x.index),
+		x.state,
 	}
-	for _, v := range data {
-		if err := binary.Write(buf, binary.LittleEndian, v); err != nil {
-			panic(strings.Join([]string{msg, err.Error()}, "\n"))
-		}
-	}
-	for _, v := range x.state {
-		if err := binary.Write(buf, binary.LittleEndian, v); err != nil {
-			panic(strings.Join([]string{msg, err.Error()}, "\n"))
-		}
-	}
-	return buf.Bytes()
 }
 
 // SetState sets the internal state of the engine from a []byte
 // SetState can be used to resume from a saved state
 func (x *Xorshift1024star) SetState(b []byte) {
-	const msg = "xorshift1024star: Error decoding state"
-	const algo = "xorshift1024star"
-	buf := bytes.NewReader(b)
-	nb := make([]byte, len(algo))
-	_, err := buf.Read(nb)
-	if err != nil {
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+	if err := x.SetStateE(b); err != nil {
+		panic(err.Error())
 	}
-	if string(nb) != algo {
-		err = fmt.Errorf("Expected '%s', got '%s'", algo, string(nb))
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of Xorshift1024star, e.g. if it is truncated,
+// if its index is not in [0, 16) or if its state is all zero
+func (x *Xorshift1024star) SetStateE(b []byte) error {
+	return x.setState(state.NewDecoder("xorshift1024star", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (x *Xorshift1024star) setState(d *state.Decoder) error {
+	var seed, index uint64
+	var st [16]uint64
+	d.Read(&seed, &index, &st)
+	if index >= uint64(len(st)) {
+		d.Fail(fmt.Errorf("Invalid index %d", index))
 	}
-	if err = binary.Read(buf, binary.LittleEndian, &x.seed); err != nil {
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+	if st == [16]uint64{} {
+		d.Fail(fmt.Errorf("State must not be all zero"))
 	}
-	var index uint64
-	if err = binary.Read(buf, binary.LittleEndian, &index); err != nil {
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+	if err := d.Err(); err != nil {
+		return err
 	}
+	x.seed = seed
 	x.index = int(index)
-	for i := 0; i < len(x.state); i++ {
-		if err = binary.Read(buf, binary.LittleEndian, &x.state[i]); err != nil {
-			panic(strings.Join([]string{msg, err.Error()}, "\n"))
+	x.state = st
+	return nil
+}
+
+// Jump advances the internal state of the engine by 2^512 steps.
+// It can be used to generate 2^512 non-overlapping sub-sequences for
+// parallel computations.
+func (x *Xorshift1024star) Jump() {
+	var t [16]uint64
+	for _, p := range jumpPoly {
+		for b := uint(0); b < 64; b++ {
+			if p&(uint64(1)<<b) != 0 {
+				for j := range t {
+					t[j] ^= x.state[(j+x.index)&15]
+				}
+			}
+			_ = x.Uint64()
 		}
 	}
+	for j := range t {
+		x.state[(j+x.index)&15] = t[j]
+	}
+}
+
+// Split returns a new Xorshift1024star engine seeded, through SplitMix64 as in
+// Seed, with the next non-zero output of this engine.
+// Split advances the internal state of the engine.
+func (x *Xorshift1024star) Split() prng.Engine {
+	seed := x.Uint64()
+	for seed == 0 {
+		seed = x.Uint64()
+	}
+	return New(seed)
 }
 
 // Reset reverts the internal state of the engine to its default state,
@@ -134,3 +181,41 @@ func (x *Xorshift1024star) SetState(b []byte) {
 func (x *Xorshift1024star) Reset() {
 	x.Seed(x.seed)
 }
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (x *Xorshift1024star) MarshalBinary() ([]byte, error) { return x.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (x *Xorshift1024star) UnmarshalBinary(b []byte) error { return x.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (x *Xorshift1024star) MarshalText() ([]byte, error) {
+	return state.MarshalText(x.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (x *Xorshift1024star) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("xorshift1024star", text)
+	if err != nil {
+		return err
+	}
+	return x.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (x *Xorshift1024star) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("xorshift1024star", x.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (x *Xorshift1024star) UnmarshalJSON(data []byte) error {
+	return x.setState(state.NewJSONDecoder("xorshift1024star", data))
+}
@@ -119,6 +119,32 @@ func Test_Xorshift1024star_Float64OO(t *testing.T) {
 	prngtest.CompareDraws(t, e, filenames, longTest)
 }
 
+func Test_Xorshift1024star_Jump(t *testing.T) {
+	e := xorshift1024star.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "*-jump-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+
+	// Checking that Jump commutes with Uint64 when the index is non-zero
+	assert := assert.New(t)
+	r1 := xorshift1024star.New(1)
+	r2 := xorshift1024star.New(1)
+	for i := 0; i < 21; i++ {
+		_ = r1.Uint64()
+	}
+	r1.Jump()
+	r2.Jump()
+	for i := 0; i < 21; i++ {
+		_ = r2.Uint64()
+	}
+	for i := 0; i < 32; i++ {
+		assert.Equal(r2.Uint64(), r1.Uint64())
+	}
+}
+
+func Test_Xorshift1024star_Split(t *testing.T) {
+	prngtest.CheckSplit(t, xorshift1024star.New(20170612))
+}
+
 // Benchmarks
 func Benchmark_Xorshift1024star_Uint64(b *testing.B) {
 	rng := xorshift1024star.New(0)
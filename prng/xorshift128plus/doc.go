@@ -1,6 +1,9 @@
 // Package xorshift128plus provides implementation for xorshift PRNG
 // algorithm with addition as the non-linear transformation function.
 //
+// Xorshift128Plus implements prng.Jumper: Jump advances the engine by 2^64 steps
+// using the jump polynomial published with the reference implementation.
+//
 // References:
 //
 // http://xoroshiro.di.unimi.it/
@@ -1,21 +1,32 @@
 package xorshift128plus
 
 import (
-	"bytes"
-	"encoding/binary"
+	"encoding/gob"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
 	"github.com/shivakar/random/prng/splitmix64"
 )
 
 var (
 	xorshift128plus *Xorshift128Plus
-	_               prng.Engine = xorshift128plus
+	_               prng.Engine        = xorshift128plus
+	_               prng.Jumper        = xorshift128plus
+	_               prng.Splitter      = xorshift128plus
+	_               prng.StateRestorer = xorshift128plus
 )
 
+func init() {
+	prng.Register("xorshift128plus", func(seed uint64) prng.Engine { return New(seed) })
+	gob.Register(new(Xorshift128Plus))
+}
+
+// jumpPoly is x^(2^64) modulo the characteristic polynomial of the linear
+// engine, one coefficient per bit
+var jumpPoly = [2]uint64{0x8a5cd789635d2dff, 0x121fd2155c472f96}
+
 // Xorshift128Plus implements a Xorshift PRNG with 128 bits of state and
 // a maximal period of 2^128-1. The algorithm uses addition as the non-linear
 // transformation function
@@ -76,46 +87,77 @@ func (x *Xorshift128Plus) GetSeed() uint64 { return x.seed }
 // GetState returns the internal state of the engine as []byte
 // GetState can be used to save the state, e.g. to a file
 func (x *Xorshift128Plus) GetState() []byte {
-	const msg = "xorshift128plus: Error encoding state"
-	buf := new(bytes.Buffer)
-	data := []interface{}{
-		[]byte("xorshift128plus"),
+	return state.Encode("xorshift128plus", x.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (x *Xorshift128Plus) fields() []interface{} {
+	return []interface{}{
 		uint64(x.seed),
 		uint64(x.state[0]),
 		uint64(x.state[1]),
 	}
-	for _, v := range data {
-		if err := binary.Write(buf, binary.LittleEndian, v); err != nil {
-			panic(strings.Join([]string{msg, err.Error()}, "\n"))
-		}
-	}
-	return buf.Bytes()
 }
 
 // SetState sets the internal state of the engine from a []byte
 // SetState can be used to resume from a saved state
 func (x *Xorshift128Plus) SetState(b []byte) {
-	const msg = "xorshift128plus: Error decoding state"
-	const algo = "xorshift128plus"
-	buf := bytes.NewReader(b)
-	nb := make([]byte, len(algo))
-	_, err := buf.Read(nb)
-	if err != nil {
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+	if err := x.SetStateE(b); err != nil {
+		panic(err.Error())
 	}
-	if string(nb) != algo {
-		err = fmt.Errorf("Expected '%s', got '%s'", algo, string(nb))
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of Xorshift128Plus, e.g. if it is truncated
+// or if its state is all zero
+func (x *Xorshift128Plus) SetStateE(b []byte) error {
+	return x.setState(state.NewDecoder("xorshift128plus", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (x *Xorshift128Plus) setState(d *state.Decoder) error {
+	var seed uint64
+	var st [2]uint64
+	d.Read(&seed, &st)
+	if st == [2]uint64{} {
+		d.Fail(fmt.Errorf("State must not be all zero"))
 	}
-	if err = binary.Read(buf, binary.LittleEndian, &x.seed); err != nil {
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+	if err := d.Err(); err != nil {
+		return err
 	}
-	if err = binary.Read(buf, binary.LittleEndian, &x.state[0]); err != nil {
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+	x.seed = seed
+	x.state = st
+	return nil
+}
+
+// Jump advances the internal state of the engine by 2^64 steps.
+// It can be used to generate 2^64 non-overlapping sub-sequences for
+// parallel computations.
+func (x *Xorshift128Plus) Jump() {
+	var s0, s1 uint64
+	for _, p := range jumpPoly {
+		for b := uint(0); b < 64; b++ {
+			if p&(uint64(1)<<b) != 0 {
+				s0 ^= x.state[0]
+				s1 ^= x.state[1]
+			}
+			_ = x.Uint64()
+		}
 	}
-	if err = binary.Read(buf, binary.LittleEndian, &x.state[1]); err != nil {
-		panic(strings.Join([]string{msg, err.Error()}, "\n"))
+	x.state[0] = s0
+	x.state[1] = s1
+}
+
+// Split returns a new Xorshift128Plus engine seeded, through SplitMix64 as in
+// Seed, with the next non-zero output of this engine.
+// Split advances the internal state of the engine.
+func (x *Xorshift128Plus) Split() prng.Engine {
+	seed := x.Uint64()
+	for seed == 0 {
+		seed = x.Uint64()
 	}
+	return New(seed)
 }
 
 // Reset reverts the internal state of the engine to its default state,
@@ -123,3 +165,41 @@ func (x *Xorshift128Plus) SetState(b []byte) {
 func (x *Xorshift128Plus) Reset() {
 	x.Seed(x.seed)
 }
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (x *Xorshift128Plus) MarshalBinary() ([]byte, error) { return x.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (x *Xorshift128Plus) UnmarshalBinary(b []byte) error { return x.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (x *Xorshift128Plus) MarshalText() ([]byte, error) {
+	return state.MarshalText(x.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (x *Xorshift128Plus) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("xorshift128plus", text)
+	if err != nil {
+		return err
+	}
+	return x.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (x *Xorshift128Plus) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("xorshift128plus", x.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (x *Xorshift128Plus) UnmarshalJSON(data []byte) error {
+	return x.setState(state.NewJSONDecoder("xorshift128plus", data))
+}
@@ -119,6 +119,16 @@ func Test_Xorshift128Plus_Float64OO(t *testing.T) {
 	prngtest.CompareDraws(t, e, filenames, longTest)
 }
 
+func Test_Xorshift128Plus_Jump(t *testing.T) {
+	e := xorshift128plus.New(0)
+	filenames := prngtest.GetDataFiles(datadir, "*-jump-*.txt")
+	prngtest.CompareDraws(t, e, filenames, longTest)
+}
+
+func Test_Xorshift128Plus_Split(t *testing.T) {
+	prngtest.CheckSplit(t, xorshift128plus.New(20170612))
+}
+
 // Benchmarks
 func Benchmark_Xorshift128Plus_Uint64(b *testing.B) {
 	rng := xorshift128plus.New(0)
@@ -0,0 +1,24 @@
+// Package xoshiro256 provides implementation for the xoshiro256 family of
+// PRNG algorithms with 256 bits of state and a period of 2^256-1.
+//
+// The engine supports three scramblers, selected at construction:
+//
+//   - StarStar (xoshiro256**): the recommended all-purpose generator
+//   - PlusPlus (xoshiro256++): as fast as StarStar with similar quality
+//   - Plus (xoshiro256+): fastest, intended for floating-point numbers; the
+//     lowest three bits of its output have low linear complexity
+//
+// Xoshiro256 implements prng.LongJumper: Jump advances the engine by 2^128
+// steps and LongJump by 2^192 steps, using the jump polynomials published
+// with the reference implementation.
+//
+// References:
+//
+// D. Blackman and S. Vigna, "Scrambled Linear Pseudorandom Number
+// Generators", ACM Transactions on Mathematical Software 47(4) (2021).
+//
+// http://prng.di.unimi.it/
+// http://prng.di.unimi.it/xoshiro256starstar.c
+// http://prng.di.unimi.it/xoshiro256plusplus.c
+// http://prng.di.unimi.it/xoshiro256plus.c
+package xoshiro256
@@ -0,0 +1,290 @@
+package xoshiro256
+
+import (
+	"encoding/gob"
+	"fmt"
+	"math/bits"
+	"time"
+
+	"github.com/shivakar/random/prng"
+	"github.com/shivakar/random/prng/internal/state"
+	"github.com/shivakar/random/prng/splitmix64"
+)
+
+var (
+	xoshiro256 *Xoshiro256
+	_          prng.Engine        = xoshiro256
+	_          prng.LongJumper    = xoshiro256
+	_          prng.Splitter      = xoshiro256
+	_          prng.StateRestorer = xoshiro256
+)
+
+func init() {
+	prng.Register("xoshiro256", func(seed uint64) prng.Engine { return New(seed, StarStar) })
+	gob.Register(new(Xoshiro256))
+}
+
+// Jump polynomials, i.e. x^(2^128) and x^(2^192) modulo the characteristic
+// polynomial of the linear engine, one coefficient per bit
+var (
+	jumpPoly = [4]uint64{
+		0x180ec6d33cfd0aba, 0xd5a61266f0c9392c,
+		0xa9582618e03fc9aa, 0x39abdc4529b1661c,
+	}
+	longJumpPoly = [4]uint64{
+		0x76e15d3efefdcbbf, 0xc5004e441c522fb3,
+		0x77710069854ee241, 0x39109bb02acbe635,
+	}
+)
+
+// Scrambler selects the non-linear output function of a Xoshiro256 engine
+type Scrambler uint64
+
+// Scramblers supported by Xoshiro256
+const (
+	// StarStar computes rotl(s1 * 5, 7) * 9
+	StarStar Scrambler = iota
+	// PlusPlus computes rotl(s0 + s3, 23) + s0
+	PlusPlus
+	// Plus computes s0 + s3
+	Plus
+)
+
+// String returns the name of the scrambler as used in the name of the
+// algorithm, e.g. "starstar" for xoshiro256starstar
+func (s Scrambler) String() string {
+	switch s {
+	case StarStar:
+		return "starstar"
+	case PlusPlus:
+		return "plusplus"
+	case Plus:
+		return "plus"
+	}
+	return fmt.Sprintf("Scrambler(%d)", uint64(s))
+}
+
+// Xoshiro256 implements a xoshiro PRNG with 256 bits of state and a maximal
+// period of 2^256-1, with one of the scramblers StarStar, PlusPlus or Plus
+// as the non-linear transformation function
+type Xoshiro256 struct {
+	seed      uint64
+	scrambler Scrambler
+	state     [4]uint64
+}
+
+// New returns a new instance of the Xoshiro256 PRNG Engine using the given
+// scrambler.
+// If the seed provided is 0, the engine is initialized with current time
+func New(seed uint64, scrambler Scrambler) *Xoshiro256 {
+	if scrambler > Plus {
+		panic(fmt.Sprintf("xoshiro256: Unknown scrambler %v", scrambler))
+	}
+	r := new(Xoshiro256)
+	r.scrambler = scrambler
+	r.Seed(seed)
+	return r
+}
+
+// Scrambler returns the scrambler used by the engine
+func (x *Xoshiro256) Scrambler() Scrambler { return x.scrambler }
+
+/*
+ * Implement 'Engine' interface
+ */
+
+// Uint64 returns a pseudo-random 64-bit value in [0, 2^64) as a uint64.
+// Uint64 advances the internal state of the engine.
+func (x *Xoshiro256) Uint64() uint64 {
+	var result uint64
+	switch x.scrambler {
+	case StarStar:
+		result = bits.RotateLeft64(x.state[1]*5, 7) * 9
+	case PlusPlus:
+		result = bits.RotateLeft64(x.state[0]+x.state[3], 23) + x.state[0]
+	default:
+		result = x.state[0] + x.state[3]
+	}
+	x.next()
+	return result
+}
+
+// next advances the linear engine by one step
+func (x *Xoshiro256) next() {
+	t := x.state[1] << 17
+	x.state[2] ^= x.state[0]
+	x.state[3] ^= x.state[1]
+	x.state[1] ^= x.state[2]
+	x.state[0] ^= x.state[3]
+	x.state[2] ^= t
+	x.state[3] = bits.RotateLeft64(x.state[3], 45)
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0) as a float64.
+// Float64 advances the internal state of the engine.
+func (x *Xoshiro256) Float64() float64 {
+	return float64(x.Uint64()>>11) / float64(1<<53)
+}
+
+// Float64OO returns a pseudo-random number in (0.0, 1.0) as a float64.
+// Float6400 advances the internal state of the engine.
+func (x *Xoshiro256) Float64OO() float64 {
+	return (float64(x.Uint64()>>12) + float64(0.5)) / float64(1<<52)
+}
+
+// Seed uses the provided value to initialize the engine
+func (x *Xoshiro256) Seed(seed uint64) {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	x.seed = seed
+	ms := splitmix64.New(seed)
+	for i := range x.state {
+		x.state[i] = ms.Uint64()
+	}
+}
+
+// GetSeed returns the seed used to initialize the engine
+func (x *Xoshiro256) GetSeed() uint64 { return x.seed }
+
+// GetState returns the internal state of the engine as []byte
+// GetState can be used to save the state, e.g. to a file
+func (x *Xoshiro256) GetState() []byte {
+	return state.Encode("xoshiro256", x.fields()...)
+}
+
+// fields returns the fields of the internal state of the engine
+func (x *Xoshiro256) fields() []interface{} {
+	return []interface{}{
+		uint64(x.seed),
+		uint64(x.scrambler),
+		uint64(x.state[0]),
+		uint64(x.state[1]),
+		uint64(x.state[2]),
+		uint64(x.state[3]),
+	}
+}
+
+// SetState sets the internal state of the engine from a []byte
+// SetState can be used to resume from a saved state
+func (x *Xoshiro256) SetState(b []byte) {
+	if err := x.SetStateE(b); err != nil {
+		panic(err.Error())
+	}
+}
+
+// SetStateE sets the internal state of the engine from a []byte, returning
+// an error if it is not a state of Xoshiro256, e.g. if it is truncated, if
+// its scrambler is unknown or if its state is all zero
+func (x *Xoshiro256) SetStateE(b []byte) error {
+	return x.setState(state.NewDecoder("xoshiro256", b))
+}
+
+// setState sets the internal state of the engine from the fields decoded
+// by d
+func (x *Xoshiro256) setState(d *state.Decoder) error {
+	var seed, scrambler uint64
+	var st [4]uint64
+	d.Read(&seed, &scrambler)
+	if Scrambler(scrambler) > Plus {
+		d.Fail(fmt.Errorf("Unknown scrambler %d", scrambler))
+	}
+	d.Read(&st)
+	if st == [4]uint64{} {
+		d.Fail(fmt.Errorf("State must not be all zero"))
+	}
+	if err := d.Err(); err != nil {
+		return err
+	}
+	x.seed = seed
+	x.scrambler = Scrambler(scrambler)
+	x.state = st
+	return nil
+}
+
+// Reset reverts the internal state of the engine to its default state,
+// except the seed and the scrambler
+func (x *Xoshiro256) Reset() {
+	x.Seed(x.seed)
+}
+
+// Jump advances the internal state of the engine by 2^128 steps.
+// It can be used to generate 2^128 non-overlapping sub-sequences for
+// parallel computations.
+func (x *Xoshiro256) Jump() {
+	x.jump(jumpPoly)
+}
+
+// LongJump advances the internal state of the engine by 2^192 steps.
+// It can be used to generate 2^64 starting points, from each of which Jump
+// generates 2^64 non-overlapping sub-sequences for distributed computations.
+func (x *Xoshiro256) LongJump() {
+	x.jump(longJumpPoly)
+}
+
+// jump sets the state of the engine to poly(T)*state, where T is the
+// transition function of the engine
+func (x *Xoshiro256) jump(poly [4]uint64) {
+	var t [4]uint64
+	for _, p := range poly {
+		for b := uint(0); b < 64; b++ {
+			if p&(uint64(1)<<b) != 0 {
+				for j := range t {
+					t[j] ^= x.state[j]
+				}
+			}
+			x.next()
+		}
+	}
+	x.state = t
+}
+
+// Split returns a new Xoshiro256 engine with the same scrambler, seeded,
+// through SplitMix64 as in Seed, with the next non-zero output of this
+// engine.
+// Split advances the internal state of the engine.
+func (x *Xoshiro256) Split() prng.Engine {
+	seed := x.Uint64()
+	for seed == 0 {
+		seed = x.Uint64()
+	}
+	return New(seed, x.scrambler)
+}
+
+/*
+ * Implement encoding interfaces
+ */
+
+// MarshalBinary returns the internal state of the engine as []byte, as
+// GetState
+func (x *Xoshiro256) MarshalBinary() ([]byte, error) { return x.GetState(), nil }
+
+// UnmarshalBinary sets the internal state of the engine from a []byte, as
+// SetStateE
+func (x *Xoshiro256) UnmarshalBinary(b []byte) error { return x.SetStateE(b) }
+
+// MarshalText returns the internal state of the engine encoded in base64
+func (x *Xoshiro256) MarshalText() ([]byte, error) {
+	return state.MarshalText(x.GetState())
+}
+
+// UnmarshalText sets the internal state of the engine from its encoding by
+// MarshalText
+func (x *Xoshiro256) UnmarshalText(text []byte) error {
+	b, err := state.UnmarshalText("xoshiro256", text)
+	if err != nil {
+		return err
+	}
+	return x.SetStateE(b)
+}
+
+// MarshalJSON returns the internal state of the engine encoded in JSON
+func (x *Xoshiro256) MarshalJSON() ([]byte, error) {
+	return state.MarshalJSON("xoshiro256", x.fields()...)
+}
+
+// UnmarshalJSON sets the internal state of the engine from its encoding by
+// MarshalJSON
+func (x *Xoshiro256) UnmarshalJSON(data []byte) error {
+	return x.setState(state.NewJSONDecoder("xoshiro256", data))
+}
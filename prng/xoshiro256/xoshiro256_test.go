@@ -0,0 +1,205 @@
+package xoshiro256_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivakar/random/prng/internal/prngtest"
+	"github.com/shivakar/random/prng/xoshiro256"
+	"github.com/stretchr/testify/assert"
+)
+
+var datadir = filepath.Join("..", "..", "data", "xoshiro256")
+var longTest bool
+
+var scramblers = []xoshiro256.Scrambler{
+	xoshiro256.StarStar, xoshiro256.PlusPlus, xoshiro256.Plus,
+}
+
+// TestMain is the entry point for testing
+func TestMain(m *testing.M) {
+	longTest = prngtest.ParseCommandLine()
+	os.Exit(m.Run())
+}
+
+// compareDraws compares output of the engine for each scrambler against
+// the draws files of that scrambler matching pattern
+func compareDraws(t *testing.T, pattern string) {
+	for _, s := range scramblers {
+		e := xoshiro256.New(0, s)
+		filenames := prngtest.GetDataFiles(datadir, "xoshiro256"+s.String()+pattern)
+		prngtest.CompareDraws(t, e, filenames, longTest)
+	}
+}
+
+func Test_Xoshiro256_GetSetSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seeds := []uint64{1, 5, 10, 1024, 200000}
+	r := xoshiro256.New(0, xoshiro256.StarStar)
+	for _, seed := range seeds {
+		r.Seed(seed)
+		assert.Equal(seed, r.GetSeed())
+	}
+	r.Seed(0)
+	assert.NotEqual(0, r.GetSeed())
+
+	assert.Panics(func() {
+		_ = xoshiro256.New(1, xoshiro256.Scrambler(3))
+	})
+}
+
+func Test_Xoshiro256_GetSetState(t *testing.T) {
+	assert := assert.New(t)
+
+	// Checking seed and scrambler remain same after getting and setting
+	// states
+	seeds := []uint64{1, 5, 10, 1024}
+	states := make([][]byte, len(seeds))
+	for i, seed := range seeds {
+		r := xoshiro256.New(seed, scramblers[i%len(scramblers)])
+		states[i] = r.GetState()
+	}
+	for i, state := range states {
+		r := xoshiro256.New(0, xoshiro256.StarStar)
+		r.SetState(state)
+		assert.Equal(seeds[i], r.GetSeed())
+		assert.Equal(scramblers[i%len(scramblers)], r.Scrambler())
+	}
+
+	// Checking that the streams remain same after getting and setting states
+	r1 := xoshiro256.New(0, xoshiro256.PlusPlus)
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+	}
+	r2 := xoshiro256.New(0, xoshiro256.StarStar)
+	r2.SetState(r1.GetState())
+	for i := 0; i < 10; i++ {
+		_ = r1.Uint64()
+		_ = r2.Uint64()
+	}
+	assert.Equal(r1.Uint64(), r2.Uint64())
+	assert.Equal(r1.Float64(), r2.Float64())
+	assert.Equal(r1.Float64OO(), r2.Float64OO())
+
+	// Checking cases where SetState should panic
+	assert.Panics(func() {
+		r1 := xoshiro256.New(0, xoshiro256.StarStar)
+		r1.SetState([]byte("Hello"))
+	})
+	assert.Panics(func() {
+		r1 := xoshiro256.New(0, xoshiro256.StarStar)
+		r1.SetState(nil)
+	})
+
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("xoshiro257"))
+	assert.Panics(func() {
+		r1 := xoshiro256.New(0, xoshiro256.StarStar)
+		r1.SetState(buf.Bytes())
+	})
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("xoshiro256"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(7))
+	assert.Panics(func() {
+		r1 := xoshiro256.New(0, xoshiro256.StarStar)
+		r1.SetState(buf.Bytes())
+	})
+	buf = new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, []byte("xoshiro256"))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(0))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(10))
+	_ = binary.Write(buf, binary.LittleEndian, []byte("h"))
+	assert.Panics(func() {
+		r1 := xoshiro256.New(0, xoshiro256.StarStar)
+		r1.SetState(buf.Bytes())
+	})
+}
+
+func Test_Xoshiro256_Uint64(t *testing.T) {
+	compareDraws(t, "-*-uint64-*.txt")
+}
+
+func Test_Xoshiro256_Float64(t *testing.T) {
+	compareDraws(t, "-*-float64-*.txt")
+}
+
+func Test_Xoshiro256_Float64OO(t *testing.T) {
+	compareDraws(t, "-*-float64oo-*.txt")
+}
+
+func Test_Xoshiro256_Jump(t *testing.T) {
+	compareDraws(t, "-*-jump-*.txt")
+}
+
+func Test_Xoshiro256_LongJump(t *testing.T) {
+	compareDraws(t, "-*-longjump-*.txt")
+}
+
+func Test_Xoshiro256_Split(t *testing.T) {
+	prngtest.CheckSplit(t, xoshiro256.New(20170612, xoshiro256.Plus))
+
+	// Checking that split engines keep the scrambler
+	c := xoshiro256.New(20170612, xoshiro256.Plus).Split()
+	assert.Equal(t, xoshiro256.Plus, c.(*xoshiro256.Xoshiro256).Scrambler())
+}
+
+// Benchmarks
+func Benchmark_Xoshiro256StarStar_Uint64(b *testing.B) {
+	rng := xoshiro256.New(0, xoshiro256.StarStar)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_Xoshiro256PlusPlus_Uint64(b *testing.B) {
+	rng := xoshiro256.New(0, xoshiro256.PlusPlus)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_Xoshiro256Plus_Uint64(b *testing.B) {
+	rng := xoshiro256.New(0, xoshiro256.Plus)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Uint64()
+	}
+}
+
+func Benchmark_Xoshiro256StarStar_Float64(b *testing.B) {
+	rng := xoshiro256.New(0, xoshiro256.StarStar)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64()
+	}
+}
+
+func Benchmark_Xoshiro256StarStar_Float64OO(b *testing.B) {
+	rng := xoshiro256.New(0, xoshiro256.StarStar)
+	for i := 0; i < b.N; i++ {
+		_ = rng.Float64OO()
+	}
+}
+
+// Example - Xoshiro256** Usage
+func ExampleXoshiro256() {
+	// Create a new instance of the xoshiro256** engine
+	r := xoshiro256.New(20170612, xoshiro256.StarStar)
+
+	fmt.Println("Xoshiro256**: seed = 20170612; Uint64()")
+	for i := 0; i < 3; i++ {
+		// Draw 64 random bits as a uint64
+		fmt.Println(r.Uint64())
+	}
+
+	// Output:
+	// Xoshiro256**: seed = 20170612; Uint64()
+	// 10258490577525515897
+	// 10487961096822918816
+	// 2723034098728122062
+}